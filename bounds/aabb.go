@@ -0,0 +1,266 @@
+// Package bounds provides structs for handling 2D bounding volumes
+package bounds
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+const (
+	// Min represents AABB's Min property JSON tag
+	Min = "min"
+	// Max represents AABB's Max property JSON tag
+	Max = "max"
+
+	aabbJSONFormat = `{"` + Min + `":%s,"` + Max + `":%s}`
+	aabbJSONEmpty  = `null`
+)
+
+// AABB represents a 2D axis-aligned bounding box, given by its minimum and maximum corners
+type AABB struct {
+	Min vector.Vector2 `json:"min"`
+	Max vector.Vector2 `json:"max"`
+}
+
+// NewAABB creates a box from two opposite corners, in any order
+func NewAABB(a, b vector.Vector2) AABB {
+	return AABB{
+		Min: vector.Vector2{X: math.Min(a.X, b.X), Y: math.Min(a.Y, b.Y)},
+		Max: vector.Vector2{X: math.Max(a.X, b.X), Y: math.Max(a.Y, b.Y)},
+	}
+}
+
+// NewAABBFromCenter creates a box from its center and its extents, the half of its size
+func NewAABBFromCenter(center, extents vector.Vector2) AABB {
+	extents = vector.Vector2{X: math.Abs(extents.X), Y: math.Abs(extents.Y)}
+	return AABB{Min: center.Sub(extents), Max: center.Add(extents)}
+}
+
+// NewAABBFromPoints creates the smallest box containing every point.
+// Returns an empty box if there are no points
+func NewAABBFromPoints(points []vector.Vector2) AABB {
+	b := EmptyAABB()
+	for _, p := range points {
+		b = b.Encapsulate(p)
+	}
+	return b
+}
+
+// EmptyAABB returns a box containing no point. It is the identity of the union,
+// so a box can be grown from it by encapsulating points
+func EmptyAABB() AABB {
+	return AABB{
+		Min: vector.Vector2{X: math.Inf(1), Y: math.Inf(1)},
+		Max: vector.Vector2{X: math.Inf(-1), Y: math.Inf(-1)},
+	}
+}
+
+// IsEmpty checks if the box contains no point
+func (b AABB) IsEmpty() bool {
+	return b.Min.X > b.Max.X || b.Min.Y > b.Max.Y
+}
+
+// Center returns the center of the box
+func (b AABB) Center() vector.Vector2 {
+	return vector.Lerp(b.Min, b.Max, 0.5)
+}
+
+// Extents returns the extents of the box, the half of its size
+func (b AABB) Extents() vector.Vector2 {
+	return b.Size().Div(2)
+}
+
+// Size returns the width and the height of the box
+func (b AABB) Size() vector.Vector2 {
+	return b.Max.Sub(b.Min)
+}
+
+// Area returns the area of the box, or 0 if it is empty
+func (b AABB) Area() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+	size := b.Size()
+	return size.X * size.Y
+}
+
+// Perimeter returns the perimeter of the box, or 0 if it is empty
+func (b AABB) Perimeter() float64 {
+	if b.IsEmpty() {
+		return 0
+	}
+	size := b.Size()
+	return 2 * (size.X + size.Y)
+}
+
+// Corners returns the 4 corners of the box, in clockwise order starting at the minimum one
+func (b AABB) Corners() [4]vector.Vector2 {
+	return [4]vector.Vector2{
+		b.Min,
+		{X: b.Min.X, Y: b.Max.Y},
+		b.Max,
+		{X: b.Max.X, Y: b.Min.Y},
+	}
+}
+
+// Union returns the smallest box containing both boxes
+func (b AABB) Union(other AABB) AABB {
+	return AABB{
+		Min: vector.Vector2{X: math.Min(b.Min.X, other.Min.X), Y: math.Min(b.Min.Y, other.Min.Y)},
+		Max: vector.Vector2{X: math.Max(b.Max.X, other.Max.X), Y: math.Max(b.Max.Y, other.Max.Y)},
+	}
+}
+
+// Intersection returns the box shared by both boxes.
+// Returns false if the boxes do not overlap, in which case the returned box is empty
+func (b AABB) Intersection(other AABB) (AABB, bool) {
+	intersection := AABB{
+		Min: vector.Vector2{X: math.Max(b.Min.X, other.Min.X), Y: math.Max(b.Min.Y, other.Min.Y)},
+		Max: vector.Vector2{X: math.Min(b.Max.X, other.Max.X), Y: math.Min(b.Max.Y, other.Max.Y)},
+	}
+	if intersection.IsEmpty() {
+		return EmptyAABB(), false
+	}
+	return intersection, true
+}
+
+// Encapsulate returns the smallest box containing both the box and the point
+func (b AABB) Encapsulate(p vector.Vector2) AABB {
+	return AABB{
+		Min: vector.Vector2{X: math.Min(b.Min.X, p.X), Y: math.Min(b.Min.Y, p.Y)},
+		Max: vector.Vector2{X: math.Max(b.Max.X, p.X), Y: math.Max(b.Max.Y, p.Y)},
+	}
+}
+
+// Expand returns the box grown by the amount on every side. A negative amount shrinks it
+func (b AABB) Expand(amount float64) AABB {
+	return b.ExpandBy(vector.Vector2{X: amount, Y: amount})
+}
+
+// ExpandBy returns the box grown by the horizontal amount on its left and right sides,
+// and by the vertical amount on its bottom and top sides
+func (b AABB) ExpandBy(amount vector.Vector2) AABB {
+	return AABB{Min: b.Min.Sub(amount), Max: b.Max.Add(amount)}
+}
+
+// Translate returns the box moved by the offset
+func (b AABB) Translate(offset vector.Vector2) AABB {
+	return AABB{Min: b.Min.Add(offset), Max: b.Max.Add(offset)}
+}
+
+// ContainsPoint checks if the point lies inside the box or on its boundary
+func (b AABB) ContainsPoint(p vector.Vector2) bool {
+	return p.X >= b.Min.X && p.X <= b.Max.X && p.Y >= b.Min.Y && p.Y <= b.Max.Y
+}
+
+// Contains checks if the other box lies entirely inside the box.
+// An empty box is contained by any box
+func (b AABB) Contains(other AABB) bool {
+	if other.IsEmpty() {
+		return true
+	}
+	return other.Min.X >= b.Min.X && other.Max.X <= b.Max.X && other.Min.Y >= b.Min.Y && other.Max.Y <= b.Max.Y
+}
+
+// Overlaps checks if both boxes share at least one point, touching boundaries included
+func (b AABB) Overlaps(other AABB) bool {
+	return b.Min.X <= other.Max.X && other.Min.X <= b.Max.X && b.Min.Y <= other.Max.Y && other.Min.Y <= b.Max.Y
+}
+
+// ClosestPoint returns the point of the box closest to the point p.
+// Returns the point itself if it lies inside the box
+func (b AABB) ClosestPoint(p vector.Vector2) vector.Vector2 {
+	return vector.Vector2{
+		X: math.Max(b.Min.X, math.Min(p.X, b.Max.X)),
+		Y: math.Max(b.Min.Y, math.Min(p.Y, b.Max.Y)),
+	}
+}
+
+// Distance returns the distance between the point p and the box, or 0 if it lies inside the box
+func (b AABB) Distance(p vector.Vector2) float64 {
+	return b.ClosestPoint(p).Distance(p)
+}
+
+// IntersectRay intersects the ray with the box, using the slab method.
+//
+// Receives the origin and the direction of the ray, which does not need to be normalized.
+// The parameters returned are multiples of the direction, where the ray enters and leaves the box,
+// and the entry is 0 if the origin lies inside the box.
+// Returns false if the ray misses the box
+func (b AABB) IntersectRay(origin, direction vector.Vector2) (enter, exit float64, ok bool) {
+	return b.intersectSlabs(origin, direction, 0, math.Inf(1))
+}
+
+// IntersectSegment intersects the segment between the points from and to with the box, using the slab method.
+//
+// The parameters returned are fractions of the segment, from 0 at its start to 1 at its end, where the segment enters
+// and leaves the box, and they are clamped to the segment if it starts or ends inside the box.
+// Returns false if the segment misses the box
+func (b AABB) IntersectSegment(from, to vector.Vector2) (enter, exit float64, ok bool) {
+	return b.intersectSlabs(from, from.To(to), 0, 1)
+}
+
+// intersectSlabs clips the parameter interval [enter, exit] of the line through the origin along the direction
+// to the horizontal and vertical slabs of the box
+func (b AABB) intersectSlabs(origin, direction vector.Vector2, enter, exit float64) (float64, float64, bool) {
+	if b.IsEmpty() {
+		return 0, 0, false
+	}
+
+	origins := [2]float64{origin.X, origin.Y}
+	directions := [2]float64{direction.X, direction.Y}
+	mins := [2]float64{b.Min.X, b.Min.Y}
+	maxs := [2]float64{b.Max.X, b.Max.Y}
+	for axis := range origins {
+		o, d := origins[axis], directions[axis]
+		if d == 0 {
+			// the line is parallel to the slab, so it either lies within it or misses the box
+			if o < mins[axis] || o > maxs[axis] {
+				return 0, 0, false
+			}
+			continue
+		}
+
+		near := (mins[axis] - o) / d
+		far := (maxs[axis] - o) / d
+		if near > far {
+			near, far = far, near
+		}
+		enter = math.Max(enter, near)
+		exit = math.Min(exit, far)
+		if enter > exit {
+			return 0, 0, false
+		}
+	}
+	return enter, exit, true
+}
+
+// MarshalJSON returns a JSON string with the minimum and maximum corners of the box, or null if the box is empty,
+// as its infinite corners cannot be written in JSON.
+// Implements the interface json.Marshaler
+func (b AABB) MarshalJSON() ([]byte, error) {
+	if b.IsEmpty() {
+		return []byte(aabbJSONEmpty), nil
+	}
+	min, _ := b.Min.MarshalJSON()
+	max, _ := b.Max.MarshalJSON()
+	return []byte(fmt.Sprintf(aabbJSONFormat, min, max)), nil
+}
+
+// UnmarshalJSON reads the minimum and maximum corners of the box from a JSON string, where null stands for
+// the empty box.
+// Implements the interface json.Unmarshaler
+func (b *AABB) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(bytes.TrimSpace(data), []byte(aabbJSONEmpty)) {
+		*b = EmptyAABB()
+		return nil
+	}
+
+	// the alias has the same fields without the methods, so the corners are decoded as usual
+	type corners AABB
+	return json.Unmarshal(data, (*corners)(b))
+}
@@ -0,0 +1,224 @@
+package bounds
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry"
+	"github.com/mindera-gaming/go-math/rotation/matrix"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// OBB represents a 2D oriented bounding box, given by its center, its extents along its own axes and its rotation.
+// A zero rotation matrix is treated as the identity, so a box without rotation may leave it out
+type OBB struct {
+	Center   vector.Vector2 `json:"center"`
+	Extents  vector.Vector2 `json:"extents"` // the half of the width and the height of the box, before rotating it
+	Rotation matrix.Matrix  `json:"rotation"`
+}
+
+// NewOBB creates a box from its center, its extents and its rotation
+func NewOBB(center, extents vector.Vector2, rotation matrix.Matrix) OBB {
+	o := OBB{Center: center, Extents: vector.Vector2{X: math.Abs(extents.X), Y: math.Abs(extents.Y)}}
+	if rotation != (matrix.Matrix{}) {
+		o.Rotation = rotation.Orthonormalized()
+	}
+	return o
+}
+
+// NewOBBFromAABB creates a box without rotation covering the same area as the axis-aligned box
+func NewOBBFromAABB(b AABB) OBB {
+	return OBB{Center: b.Center(), Extents: b.Extents(), Rotation: matrix.Identity()}
+}
+
+// Axes returns the unit vectors along the width and the height of the box
+func (o OBB) Axes() (x, y vector.Vector2) {
+	if o.Rotation == (matrix.Matrix{}) {
+		return vector.Right(), vector.Up()
+	}
+	rotation := o.Rotation.Orthonormalized()
+	return rotation.Rotate(vector.Right()), rotation.Rotate(vector.Up())
+}
+
+// Corners returns the 4 corners of the box, in clockwise order.
+// Without rotation, they start at the minimum corner, as the corners of an AABB
+func (o OBB) Corners() [4]vector.Vector2 {
+	x, y := o.Axes()
+	x = x.Mul(o.Extents.X)
+	y = y.Mul(o.Extents.Y)
+	return [4]vector.Vector2{
+		o.Center.Sub(x).Sub(y),
+		o.Center.Sub(x).Add(y),
+		o.Center.Add(x).Add(y),
+		o.Center.Add(x).Sub(y),
+	}
+}
+
+// Bounds returns the smallest axis-aligned box containing the box
+func (o OBB) Bounds() AABB {
+	x, y := o.Axes()
+	extents := vector.Vector2{
+		X: o.Extents.X*math.Abs(x.X) + o.Extents.Y*math.Abs(y.X),
+		Y: o.Extents.X*math.Abs(x.Y) + o.Extents.Y*math.Abs(y.Y),
+	}
+	return NewAABBFromCenter(o.Center, extents)
+}
+
+// Area returns the area of the box
+func (o OBB) Area() float64 {
+	return 4 * o.Extents.X * o.Extents.Y
+}
+
+// Perimeter returns the perimeter of the box
+func (o OBB) Perimeter() float64 {
+	return 4 * (o.Extents.X + o.Extents.Y)
+}
+
+// ToLocal transforms the point into the space of the box, where its center is the origin and its axes are
+// the X and Y axes
+func (o OBB) ToLocal(p vector.Vector2) vector.Vector2 {
+	x, y := o.Axes()
+	d := o.Center.To(p)
+	return vector.Vector2{X: d.Dot(x), Y: d.Dot(y)}
+}
+
+// ToWorld transforms the point from the space of the box back into world space
+func (o OBB) ToWorld(p vector.Vector2) vector.Vector2 {
+	x, y := o.Axes()
+	return o.Center.Add(x.Mul(p.X)).Add(y.Mul(p.Y))
+}
+
+// ContainsPoint checks if the point lies inside the box or on its boundary
+func (o OBB) ContainsPoint(p vector.Vector2) bool {
+	local := o.ToLocal(p)
+	return math.Abs(local.X) <= o.Extents.X && math.Abs(local.Y) <= o.Extents.Y
+}
+
+// ClosestPoint returns the point of the box closest to the point p.
+// Returns the point itself if it lies inside the box
+func (o OBB) ClosestPoint(p vector.Vector2) vector.Vector2 {
+	local := o.ToLocal(p)
+	return o.ToWorld(vector.Vector2{
+		X: math.Max(-o.Extents.X, math.Min(local.X, o.Extents.X)),
+		Y: math.Max(-o.Extents.Y, math.Min(local.Y, o.Extents.Y)),
+	})
+}
+
+// Overlaps checks if both boxes share at least one point, touching boundaries included,
+// using the separating axis theorem
+func (o OBB) Overlaps(other OBB) bool {
+	x, y := o.Axes()
+	otherX, otherY := other.Axes()
+	d := o.Center.To(other.Center)
+
+	// the boxes are apart if their projections onto the axis of either box do not overlap
+	for _, axis := range []vector.Vector2{x, y, otherX, otherY} {
+		radius := o.Extents.X*math.Abs(x.Dot(axis)) + o.Extents.Y*math.Abs(y.Dot(axis))
+		otherRadius := other.Extents.X*math.Abs(otherX.Dot(axis)) + other.Extents.Y*math.Abs(otherY.Dot(axis))
+		if math.Abs(d.Dot(axis)) > radius+otherRadius {
+			return false
+		}
+	}
+	return true
+}
+
+// OverlapsAABB checks if the box shares at least one point with the axis-aligned box, touching boundaries included,
+// using the separating axis theorem
+func (o OBB) OverlapsAABB(b AABB) bool {
+	if b.IsEmpty() {
+		return false
+	}
+	return o.Overlaps(NewOBBFromAABB(b))
+}
+
+// MinimumAreaRectangle computes the rectangle with the smallest area enclosing a set of points,
+// using the rotating calipers algorithm.
+//
+// Receives the points. One side of the rectangle is always collinear with an edge of their convex hull.
+// Returns the rectangle, which has no area if the points are collinear, or a box with no size
+// and no rotation at the origin if there are no points.
+func MinimumAreaRectangle(points []vector.Vector2) OBB {
+	return minimumRectangle(points, OBB.Area)
+}
+
+// MinimumPerimeterRectangle computes the rectangle with the smallest perimeter enclosing a set of points,
+// using the rotating calipers algorithm.
+//
+// Receives the points. One side of the rectangle is always collinear with an edge of their convex hull.
+// Returns the rectangle, which has no area if the points are collinear, or a box with no size
+// and no rotation at the origin if there are no points.
+func MinimumPerimeterRectangle(points []vector.Vector2) OBB {
+	return minimumRectangle(points, OBB.Perimeter)
+}
+
+// minimumRectangle returns the rectangle enclosing the points, aligned with an edge of their convex hull,
+// which minimizes the cost
+func minimumRectangle(points []vector.Vector2, cost func(OBB) float64) OBB {
+	hull := geometry.ConvexHull(points, geometry.HullOptions{Order: geometry.CounterClockwise})
+	switch len(hull) {
+	case 0:
+		return OBB{Rotation: matrix.Identity()}
+	case 1:
+		return OBB{Center: points[hull[0]], Rotation: matrix.Identity()}
+	case 2:
+		a, b := points[hull[0]], points[hull[1]]
+		u := a.To(b).Normalized()
+		return OBB{
+			Center:   vector.Lerp(a, b, 0.5),
+			Extents:  vector.Vector2{X: a.Distance(b) / 2},
+			Rotation: matrix.Matrix{u.X, -u.Y},
+		}
+	}
+
+	n := len(hull)
+	point := func(k int) vector.Vector2 {
+		return points[hull[k%n]]
+	}
+
+	// the calipers hold the points farthest along the edge, farthest from it and farthest back along it,
+	// and each of them only moves forward as the edges turn around the counter-clockwise hull
+	var best OBB
+	bestCost := math.Inf(1)
+	right, top, left := 0, 0, 0
+	for i := 0; i < n; i++ {
+		origin := point(i)
+		u := origin.To(point(i + 1)).Normalized()
+		v := u.Left()
+		along := func(k int) float64 {
+			return origin.To(point(k)).Dot(u)
+		}
+		across := func(k int) float64 {
+			return origin.To(point(k)).Dot(v)
+		}
+
+		if i == 0 {
+			right = 1
+		}
+		for steps := 0; steps < n && along(right+1) >= along(right); steps++ {
+			right++
+		}
+		if i == 0 {
+			top = right
+		}
+		for steps := 0; steps < n && across(top+1) >= across(top); steps++ {
+			top++
+		}
+		if i == 0 {
+			left = top
+		}
+		for steps := 0; steps < n && along(left+1) <= along(left); steps++ {
+			left++
+		}
+
+		min, max, height := along(left), along(right), across(top)
+		rectangle := OBB{
+			Center:   origin.Add(u.Mul((min + max) / 2)).Add(v.Mul(height / 2)),
+			Extents:  vector.Vector2{X: (max - min) / 2, Y: height / 2},
+			Rotation: matrix.Matrix{u.X, -u.Y},
+		}
+		if c := cost(rectangle); c < bestCost {
+			best = rectangle
+			bestCost = c
+		}
+	}
+	return best
+}
@@ -0,0 +1,7 @@
+package collision
+
+import "errors"
+
+var (
+	ErrNotConvexPolygon = errors.New("The vertex list does not define a convex polygon with an area.")
+)
@@ -0,0 +1,276 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+const (
+	maxIterations     = 64    // maximum number of iterations of the GJK and EPA algorithms
+	relativeTolerance = 1e-10 // tolerance of the GJK and EPA algorithms, relative to the size of the shapes
+)
+
+// simplexVertex is a vertex of the Minkowski difference b - a, along with the vertices of both cores forming it
+type simplexVertex struct {
+	a vector.Vector2
+	b vector.Vector2
+	w vector.Vector2
+}
+
+// OverlapGJK checks if both shapes share at least one point, touching outlines included,
+// using the Gilbert–Johnson–Keerthi algorithm
+func OverlapGJK(a Shape, ta Transform, b Shape, tb Transform) bool {
+	distance, _, _ := Distance(a, ta, b, tb)
+	return distance <= 0
+}
+
+// Distance computes the distance between both shapes, using the Gilbert–Johnson–Keerthi algorithm.
+//
+// Receives the shapes and their transforms.
+// Returns the distance between the outlines of both shapes and the points of each shape closest to the other one,
+// or a distance of 0 if they overlap, in which case the points are not meaningful
+func Distance(a Shape, ta Transform, b Shape, tb Transform) (distance float64, closestA, closestB vector.Vector2) {
+	ca, cb := a.core(ta), b.core(tb)
+	closestA, closestB, _ = gjk(ca, cb)
+	distance = closestA.Distance(closestB)
+	if distance <= ca.radius+cb.radius {
+		return 0, closestA, closestB
+	}
+
+	// the rounded parts move the closest points towards each other
+	normal := closestA.To(closestB).Div(distance)
+	return distance - ca.radius - cb.radius, closestA.Add(normal.Mul(ca.radius)), closestB.Sub(normal.Mul(cb.radius))
+}
+
+// CollideGJK computes the contact between both shapes, using the Gilbert–Johnson–Keerthi algorithm and the
+// expanding polytope algorithm.
+//
+// Receives the shapes and their transforms.
+// The distance between the cores of the shapes is found with GJK. If they overlap, EPA expands the simplex
+// enclosing the origin over the Minkowski difference of the cores, until it finds the axis of least penetration.
+// The contact points are found by clipping the faces lying along the normal.
+// Returns the contact manifold, or false if the shapes do not overlap
+func CollideGJK(a Shape, ta Transform, b Shape, tb Transform) (Manifold, bool) {
+	ca, cb := a.core(ta), b.core(tb)
+	radius := ca.radius + cb.radius
+	closestA, closestB, simplex := gjk(ca, cb)
+	if distance := closestA.Distance(closestB); distance > 0 {
+		if distance > radius {
+			return Manifold{}, false
+		}
+		normal := closestA.To(closestB).Div(distance)
+		return newManifold(ca, cb, normal, radius-distance, closestA, closestB), true
+	}
+
+	normal, depth, ok := epa(ca, cb, simplex)
+	if !ok {
+		// the Minkowski difference has no area, which the separating axes handle
+		return CollideSAT(a, ta, b, tb)
+	}
+	return newManifold(ca, cb, normal, depth+radius, ca.support(normal), cb.support(normal.Inverse())), true
+}
+
+// tolerance returns the absolute tolerance for both cores, relative to their size
+func tolerance(a, b core) float64 {
+	size := 1.0
+	for _, c := range []core{a, b} {
+		for _, v := range c.vertices {
+			size = math.Max(size, math.Max(math.Abs(v.X), math.Abs(v.Y)))
+		}
+	}
+	return relativeTolerance * size
+}
+
+// supportVertex returns the vertex of the Minkowski difference b - a farthest along the direction
+func supportVertex(a, b core, direction vector.Vector2) simplexVertex {
+	va := a.support(direction.Inverse())
+	vb := b.support(direction)
+	return simplexVertex{a: va, b: vb, w: vb.Sub(va)}
+}
+
+// gjk finds the points of both cores closest to each other.
+// Returns the closest points, which are the same if the cores overlap, and the final simplex
+func gjk(a, b core) (closestA, closestB vector.Vector2, simplex []simplexVertex) {
+	eps := tolerance(a, b)
+	// every vertex of the simplex lies on the outline of the Minkowski difference, as EPA requires
+	direction := a.vertices[0].To(b.vertices[0])
+	if direction.IsZero() {
+		direction = vector.Right()
+	}
+	simplex = []simplexVertex{supportVertex(a, b, direction)}
+	weights := []float64{1}
+	for i := 0; i < maxIterations; i++ {
+		var closest vector.Vector2
+		simplex, weights, closest = reduceSimplex(simplex)
+		if len(simplex) == 3 || closest.MagnitudeSqr() <= eps*eps {
+			// the simplex encloses or touches the origin, so the cores overlap
+			p := witness(simplex, weights, true)
+			return p, p, simplex
+		}
+
+		// the search stops when no vertex gets closer to the origin than the current simplex
+		direction = closest.Inverse()
+		v := supportVertex(a, b, direction)
+		if v.w.Dot(direction)-closest.Dot(direction) <= eps*closest.Magnitude() {
+			break
+		}
+		duplicate := false
+		for _, s := range simplex {
+			duplicate = duplicate || s.w == v.w
+		}
+		if duplicate {
+			break
+		}
+		simplex = append(simplex, v)
+	}
+
+	simplex, weights, _ = reduceSimplex(simplex)
+	return witness(simplex, weights, true), witness(simplex, weights, false), simplex
+}
+
+// witness returns the point of one of the cores, a or b, given by the weights of the simplex vertices
+func witness(simplex []simplexVertex, weights []float64, first bool) (p vector.Vector2) {
+	for i, s := range simplex {
+		if first {
+			p = p.Add(s.a.Mul(weights[i]))
+		} else {
+			p = p.Add(s.b.Mul(weights[i]))
+		}
+	}
+	return
+}
+
+// reduceSimplex finds the point of the simplex closest to the origin, and keeps only the vertices needed
+// to express it. Returns the vertices kept, their weights and the closest point
+func reduceSimplex(simplex []simplexVertex) ([]simplexVertex, []float64, vector.Vector2) {
+	switch len(simplex) {
+	case 1:
+		return simplex, []float64{1}, simplex[0].w
+	case 2:
+		return reduceSegment(simplex[0], simplex[1])
+	}
+
+	// the regions of the triangle are tested in turn, following Ericson's closest point on triangle
+	a, b, c := simplex[0], simplex[1], simplex[2]
+	ab, ac := a.w.To(b.w), a.w.To(c.w)
+	d1, d2 := ab.Dot(a.w.Inverse()), ac.Dot(a.w.Inverse())
+	if d1 <= 0 && d2 <= 0 {
+		return []simplexVertex{a}, []float64{1}, a.w
+	}
+	d3, d4 := ab.Dot(b.w.Inverse()), ac.Dot(b.w.Inverse())
+	if d3 >= 0 && d4 <= d3 {
+		return []simplexVertex{b}, []float64{1}, b.w
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return reduceSegment(a, b)
+	}
+	d5, d6 := ab.Dot(c.w.Inverse()), ac.Dot(c.w.Inverse())
+	if d6 >= 0 && d5 <= d6 {
+		return []simplexVertex{c}, []float64{1}, c.w
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return reduceSegment(a, c)
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && d4-d3 >= 0 && d5-d6 >= 0 {
+		return reduceSegment(b, c)
+	}
+
+	denominator := va + vb + vc
+	if denominator == 0 {
+		// the triangle has no area, so the closest of its edges is kept
+		best, bestWeights, bestPoint := reduceSegment(a, b)
+		for _, pair := range [][2]simplexVertex{{a, c}, {b, c}} {
+			if s, w, p := reduceSegment(pair[0], pair[1]); p.MagnitudeSqr() < bestPoint.MagnitudeSqr() {
+				best, bestWeights, bestPoint = s, w, p
+			}
+		}
+		return best, bestWeights, bestPoint
+	}
+	v, w := vb/denominator, vc/denominator
+	return simplex, []float64{1 - v - w, v, w}, a.w.Add(ab.Mul(v)).Add(ac.Mul(w))
+}
+
+// reduceSegment finds the point of the segment between both vertices closest to the origin
+func reduceSegment(a, b simplexVertex) ([]simplexVertex, []float64, vector.Vector2) {
+	ab := a.w.To(b.w)
+	lengthSqr := ab.MagnitudeSqr()
+	if lengthSqr == 0 {
+		return []simplexVertex{a}, []float64{1}, a.w
+	}
+	t := mathf.Clamp(a.w.Inverse().Dot(ab)/lengthSqr, 0, 1)
+	switch t {
+	case 0:
+		return []simplexVertex{a}, []float64{1}, a.w
+	case 1:
+		return []simplexVertex{b}, []float64{1}, b.w
+	}
+	return []simplexVertex{a, b}, []float64{1 - t, t}, a.w.Add(ab.Mul(t))
+}
+
+// epa finds the axis of least penetration of both overlapping cores, starting from the GJK simplex which
+// encloses or touches the origin. Returns the normal from a to b and the penetration depth, or false if the
+// Minkowski difference of the cores has no area
+func epa(a, b core, simplex []simplexVertex) (normal vector.Vector2, depth float64, ok bool) {
+	eps := tolerance(a, b)
+	polytope := make([]vector.Vector2, 0, len(simplex))
+	for _, s := range simplex {
+		polytope = append(polytope, s.w)
+	}
+
+	// the simplex is grown into a triangle, by searching perpendicular to what it already spans
+	if len(polytope) == 1 {
+		for _, direction := range []vector.Vector2{vector.Right(), vector.Left(), vector.Up(), vector.Down()} {
+			if w := supportVertex(a, b, direction).w; w.DistanceSqr(polytope[0]) > eps*eps {
+				polytope = append(polytope, w)
+				break
+			}
+		}
+	}
+	if len(polytope) == 2 {
+		edge := polytope[0].To(polytope[1])
+		for _, direction := range []vector.Vector2{edge.Left(), edge.Right()} {
+			if w := supportVertex(a, b, direction).w; math.Abs(edge.Cross(polytope[0].To(w))) > eps*edge.Magnitude() {
+				polytope = append(polytope, w)
+				break
+			}
+		}
+	}
+	if len(polytope) != 3 {
+		return vector.Vector2{}, 0, false
+	}
+	if polytope[0].To(polytope[1]).Cross(polytope[0].To(polytope[2])) < 0 {
+		polytope[1], polytope[2] = polytope[2], polytope[1]
+	}
+
+	// the edge of the counter-clockwise polytope closest to the origin is pushed out until it reaches the outline
+	// of the Minkowski difference
+	for i := 0; i < maxIterations; i++ {
+		closest := -1
+		for j := range polytope {
+			edge := polytope[j].To(polytope[(j+1)%len(polytope)])
+			if edge.IsZero() {
+				continue
+			}
+			n := edge.Right().Normalized()
+			if d := n.Dot(polytope[j]); closest < 0 || d < depth {
+				closest = j
+				normal = n
+				depth = d
+			}
+		}
+
+		w := supportVertex(a, b, normal).w
+		if w.Dot(normal)-depth <= eps {
+			break
+		}
+		polytope = append(polytope[:closest+1], append([]vector.Vector2{w}, polytope[closest+1:]...)...)
+	}
+
+	// the Minkowski difference b - a is left through the normal, so b moves the other way to separate them
+	return normal.Inverse(), math.Max(depth, 0), true
+}
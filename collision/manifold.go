@@ -0,0 +1,106 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/shapes"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// alignmentTolerance is how far from 1 the dot product between a face normal and the collision normal may be,
+// for the face to be clipped against the other shape
+const alignmentTolerance = 1e-6
+
+// Manifold describes the contact between two overlapping shapes
+type Manifold struct {
+	Normal vector.Vector2   // unit vector from the first shape towards the second one
+	Depth  float64          // distance the second shape must move along the normal to separate them
+	Points []vector.Vector2 // contact points in world space, halfway between the outlines of both shapes
+}
+
+// newManifold computes the contact points of two overlapping cores, given the collision normal from a to b,
+// the penetration depth and the closest points of both cores, used when no face lies along the normal.
+// The contact points are found by clipping the face of one core most aligned with the normal against the
+// most opposed face of the other core
+func newManifold(a, b core, normal vector.Vector2, depth float64, closestA, closestB vector.Vector2) Manifold {
+	m := Manifold{Normal: normal, Depth: depth}
+
+	// the reference face belongs to the core with the face most aligned with the normal
+	reference, incident := a, b
+	referenceEdge, referenceAlignment := alignedEdge(a, normal)
+	if edge, alignment := alignedEdge(b, normal.Inverse()); alignment > referenceAlignment {
+		reference, incident = b, a
+		referenceEdge, referenceAlignment = edge, alignment
+	}
+	if referenceAlignment < 1-alignmentTolerance {
+		m.Points = []vector.Vector2{vector.Lerp(closestA.Add(normal.Mul(a.radius)), closestB.Sub(normal.Mul(b.radius)), 0.5)}
+		return m
+	}
+
+	// the incident feature is the edge of the other core most opposed to the reference face, or its only vertex
+	n := outwardNormal(referenceEdge)
+	from, to := incident.vertices[0], incident.vertices[0]
+	if edge, alignment := alignedEdge(incident, n.Inverse()); alignment > -2 {
+		from, to = edge.A, edge.B
+	}
+
+	// the incident feature is clipped to the width of the reference face, and every point left within the radii of
+	// the reference face is a contact
+	tangent := referenceEdge.Direction().Normalized()
+	low := referenceEdge.A.Dot(tangent)
+	high := referenceEdge.B.Dot(tangent)
+	for _, q := range clipSegment(from, to, tangent, low, high) {
+		separation := referenceEdge.A.To(q).Dot(n)
+		if separation > reference.radius+incident.radius {
+			continue
+		}
+		surface := q.Sub(n.Mul(incident.radius))
+		referenceSurface := q.Sub(n.Mul(separation - reference.radius))
+		m.Points = append(m.Points, vector.Lerp(surface, referenceSurface, 0.5))
+	}
+	if len(m.Points) == 0 {
+		m.Points = []vector.Vector2{vector.Lerp(closestA.Add(normal.Mul(a.radius)), closestB.Sub(normal.Mul(b.radius)), 0.5)}
+	}
+	return m
+}
+
+// alignedEdge returns the edge of the core whose outward normal is most aligned with the direction, along with
+// the dot product between both. Returns an alignment of -2 if the core has no edges
+func alignedEdge(c core, direction vector.Vector2) (best shapes.Segment, alignment float64) {
+	alignment = -2
+	for _, edge := range c.edges() {
+		if a := outwardNormal(edge).Dot(direction); a > alignment {
+			best = edge
+			alignment = a
+		}
+	}
+	return
+}
+
+// clipSegment clips the segment between the points from and to, keeping the part whose projection onto the
+// tangent lies within [low, high]. Returns the endpoints of the part kept, or none if nothing is left
+func clipSegment(from, to, tangent vector.Vector2, low, high float64) []vector.Vector2 {
+	s, e := from.Dot(tangent), to.Dot(tangent)
+	if from == to {
+		if s < low || s > high {
+			return nil
+		}
+		return []vector.Vector2{from}
+	}
+	if math.Max(s, e) < low || math.Min(s, e) > high {
+		return nil
+	}
+
+	clip := func(value float64) vector.Vector2 {
+		return vector.LerpUnclamped(from, to, (value-s)/(e-s))
+	}
+	points := []vector.Vector2{from, to}
+	for i, value := range []float64{s, e} {
+		if value < low {
+			points[i] = clip(low)
+		} else if value > high {
+			points[i] = clip(high)
+		}
+	}
+	return points
+}
@@ -0,0 +1,89 @@
+package collision
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// OverlapSAT checks if both shapes share at least one point, touching outlines included,
+// using the separating axis theorem
+func OverlapSAT(a Shape, ta Transform, b Shape, tb Transform) bool {
+	_, _, _, _, ok := separatingAxis(a.core(ta), b.core(tb))
+	return ok
+}
+
+// CollideSAT computes the contact between both shapes, using the separating axis theorem.
+//
+// Receives the shapes and their transforms.
+// The normal is the axis along which the shapes overlap the least. The faces of both shapes are tested
+// as separating axes, and the direction between their closest points is used when only their rounded parts
+// overlap. The contact points are found by clipping the faces lying along the normal.
+// Returns the contact manifold, or false if the shapes do not overlap
+func CollideSAT(a Shape, ta Transform, b Shape, tb Transform) (Manifold, bool) {
+	ca, cb := a.core(ta), b.core(tb)
+	normal, depth, closestA, closestB, ok := separatingAxis(ca, cb)
+	if !ok {
+		return Manifold{}, false
+	}
+	return newManifold(ca, cb, normal, depth, closestA, closestB), true
+}
+
+// separatingAxis finds the axis of least overlap between both cores, along with the penetration depth and the
+// points of the cores closest to each other along it. Returns false if the rounded cores do not overlap
+func separatingAxis(a, b core) (normal vector.Vector2, depth float64, closestA, closestB vector.Vector2, ok bool) {
+	radius := a.radius + b.radius
+
+	// the cores overlap if no face separates them, and the face separating them the most is the axis of least
+	// penetration
+	best := math.Inf(-1)
+	for _, f := range a.faces() {
+		if separation := b.support(f.normal.Inverse()).Sub(f.point).Dot(f.normal); separation > best {
+			best = separation
+			normal = f.normal
+		}
+	}
+	for _, f := range b.faces() {
+		if separation := a.support(f.normal.Inverse()).Sub(f.point).Dot(f.normal); separation > best {
+			best = separation
+			normal = f.normal.Inverse()
+		}
+	}
+
+	switch {
+	case math.IsInf(best, -1):
+		// both cores are points, which only overlap if they are the same
+		if a.vertices[0] == b.vertices[0] {
+			return vector.Up(), radius, a.vertices[0], b.vertices[0], true
+		}
+	case best <= 0:
+		return normal, radius - best, a.support(normal), b.support(normal.Inverse()), true
+	}
+
+	// the cores are apart, so only their rounded parts can overlap, along the line between their closest points
+	closestA, closestB = closestPoints(a, b)
+	distance := closestA.Distance(closestB)
+	if distance > radius || distance == 0 {
+		return vector.Vector2{}, 0, vector.Vector2{}, vector.Vector2{}, false
+	}
+	return closestA.To(closestB).Div(distance), radius - distance, closestA, closestB, true
+}
+
+// closestPoints returns the points of both cores closest to each other, which do not overlap.
+// One of them is always a vertex of its core
+func closestPoints(a, b core) (closestA, closestB vector.Vector2) {
+	best := math.Inf(1)
+	for _, v := range a.vertices {
+		if q := b.closestPoint(v); v.DistanceSqr(q) < best {
+			best = v.DistanceSqr(q)
+			closestA, closestB = v, q
+		}
+	}
+	for _, v := range b.vertices {
+		if q := a.closestPoint(v); v.DistanceSqr(q) < best {
+			best = v.DistanceSqr(q)
+			closestA, closestB = q, v
+		}
+	}
+	return
+}
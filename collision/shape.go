@@ -0,0 +1,148 @@
+package collision
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry"
+	"github.com/mindera-gaming/go-math/shapes"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Shape represents a convex shape in its local space, which is placed in world space by a Transform.
+// It is implemented by Polygon, Circle and Capsule
+type Shape interface {
+	// core returns the shape in world space, as a convex core rounded by a radius
+	core(t Transform) core
+}
+
+// Polygon represents a convex polygon shape
+type Polygon struct {
+	vertices []vector.Vector2
+}
+
+// NewPolygon creates a convex polygon shape from its vertices, in any winding order.
+// Collinear vertices are removed.
+// Returns ErrNotConvexPolygon if the vertices do not define a convex polygon with an area
+func NewPolygon(vertices []vector.Vector2) (Polygon, error) {
+	vertices = geometry.RemoveColinearVertices(vertices, 0)
+	polygon := geometry.NewPolygon(vertices)
+	if len(vertices) < 3 || !polygon.IsConvex() {
+		return Polygon{}, ErrNotConvexPolygon
+	}
+	if polygon.WindingOrder() == geometry.Clockwise {
+		vector.ReverseSlice(&vertices)
+	}
+	return Polygon{vertices: vertices}, nil
+}
+
+// NewBox creates a rectangle shape centered on the origin from its extents, the half of its width and height
+func NewBox(extents vector.Vector2) Polygon {
+	x, y := math.Abs(extents.X), math.Abs(extents.Y)
+	return Polygon{vertices: []vector.Vector2{{X: -x, Y: -y}, {X: x, Y: -y}, {X: x, Y: y}, {X: -x, Y: y}}}
+}
+
+// Vertices returns a copy of the vertices of the polygon, in counter-clockwise order
+func (p Polygon) Vertices() []vector.Vector2 {
+	return append([]vector.Vector2{}, p.vertices...)
+}
+
+func (p Polygon) core(t Transform) core {
+	vertices := make([]vector.Vector2, len(p.vertices))
+	for i, v := range p.vertices {
+		vertices[i] = t.Apply(v)
+	}
+	return core{vertices: vertices}
+}
+
+// Circle represents a circle shape. It can be converted from and to shapes.Circle
+type Circle shapes.Circle
+
+func (c Circle) core(t Transform) core {
+	return core{vertices: []vector.Vector2{t.Apply(c.Center)}, radius: c.Radius}
+}
+
+// Capsule represents a capsule shape. It can be converted from and to shapes.Capsule
+type Capsule shapes.Capsule
+
+func (c Capsule) core(t Transform) core {
+	a, b := t.Apply(c.A), t.Apply(c.B)
+	if a == b {
+		return core{vertices: []vector.Vector2{a}, radius: c.Radius}
+	}
+	return core{vertices: []vector.Vector2{a, b}, radius: c.Radius}
+}
+
+// core is a convex shape in world space, given as a point, a segment or a counter-clockwise polygon,
+// the core, rounded by a radius
+type core struct {
+	vertices []vector.Vector2
+	radius   float64
+}
+
+// face is a side of a core, given by a point on it and its outward normal
+type face struct {
+	point  vector.Vector2
+	normal vector.Vector2
+}
+
+// support returns the vertex of the core farthest along the direction
+func (c core) support(direction vector.Vector2) vector.Vector2 {
+	best := c.vertices[0]
+	for _, v := range c.vertices[1:] {
+		if v.Dot(direction) > best.Dot(direction) {
+			best = v
+		}
+	}
+	return best
+}
+
+// edges returns the edges of the core, in counter-clockwise order. A segment has two edges, one on each side
+func (c core) edges() []shapes.Segment {
+	switch n := len(c.vertices); n {
+	case 1:
+		return nil
+	case 2:
+		return []shapes.Segment{shapes.NewSegment(c.vertices[0], c.vertices[1]), shapes.NewSegment(c.vertices[1], c.vertices[0])}
+	default:
+		edges := make([]shapes.Segment, n)
+		for i := range edges {
+			edges[i] = shapes.NewSegment(c.vertices[i], c.vertices[(i+1)%n])
+		}
+		return edges
+	}
+}
+
+// faces returns the faces of the core, which are the separating axes to test. A segment also has a face at
+// each of its ends
+func (c core) faces() []face {
+	var faces []face
+	for _, edge := range c.edges() {
+		faces = append(faces, face{point: edge.A, normal: outwardNormal(edge)})
+	}
+	if len(c.vertices) == 2 {
+		direction := c.vertices[0].To(c.vertices[1]).Normalized()
+		faces = append(faces, face{point: c.vertices[0], normal: direction.Inverse()}, face{point: c.vertices[1], normal: direction})
+	}
+	return faces
+}
+
+// closestPoint returns the point of the outline of the core closest to the point p
+func (c core) closestPoint(p vector.Vector2) vector.Vector2 {
+	edges := c.edges()
+	if len(edges) == 0 {
+		return c.vertices[0]
+	}
+
+	closest := edges[0].ClosestPoint(p)
+	for _, edge := range edges[1:] {
+		if q := edge.ClosestPoint(p); q.DistanceSqr(p) < closest.DistanceSqr(p) {
+			closest = q
+		}
+	}
+	return closest
+}
+
+// outwardNormal returns the unit normal of an edge of a counter-clockwise core, pointing outside of it
+func outwardNormal(edge shapes.Segment) vector.Vector2 {
+	return edge.Direction().Right().Normalized()
+}
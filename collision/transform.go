@@ -0,0 +1,48 @@
+// Package collision provides narrow-phase collision detection between convex polygons, circles and capsules,
+// using the separating axis theorem or the GJK and EPA algorithms
+package collision
+
+import (
+	"github.com/mindera-gaming/go-math/rotation/angle"
+	"github.com/mindera-gaming/go-math/rotation/matrix"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Transform represents the placement of a shape in world space, a rotation followed by a translation.
+// A zero rotation matrix is treated as the identity, so the zero transform leaves shapes as they are
+type Transform struct {
+	Position vector.Vector2
+	Rotation matrix.Matrix
+}
+
+// NewTransform creates a transform from a position and a rotation matrix, which is orthonormalized unless it is the
+// zero matrix
+func NewTransform(position vector.Vector2, rotation matrix.Matrix) Transform {
+	if rotation == (matrix.Matrix{}) {
+		return Transform{Position: position}
+	}
+	return Transform{Position: position, Rotation: rotation.Orthonormalized()}
+}
+
+// NewTransformFromAngle creates a transform from a position and a rotation angle
+func NewTransformFromAngle(position vector.Vector2, rotation angle.Angle) Transform {
+	return Transform{Position: position, Rotation: matrix.FromRadians(float64(rotation))}
+}
+
+// Apply transforms the point from local space into world space
+func (t Transform) Apply(p vector.Vector2) vector.Vector2 {
+	return t.rotation().Rotate(p).Add(t.Position)
+}
+
+// ApplyInverse transforms the point from world space back into local space
+func (t Transform) ApplyInverse(p vector.Vector2) vector.Vector2 {
+	return t.rotation().Transpose().Rotate(p.Sub(t.Position))
+}
+
+// rotation returns the rotation matrix of the transform, replacing the zero matrix by the identity
+func (t Transform) rotation() matrix.Matrix {
+	if t.Rotation == (matrix.Matrix{}) {
+		return matrix.Identity()
+	}
+	return t.Rotation
+}
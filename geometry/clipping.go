@@ -0,0 +1,392 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	"github.com/mindera-gaming/go-math/geometry/sweepline"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// BooleanOperation defines an enumerator representing a boolean operation between polygons.
+type BooleanOperation byte
+
+const (
+	Union BooleanOperation = iota
+	Intersection
+	Difference
+	Xor
+)
+
+// PolygonUnion returns the region covered by the subject or by the clip polygons.
+func PolygonUnion(subject, clip [][]vector.Vector2) [][]vector.Vector2 {
+	return ClipPolygons(subject, clip, Union)
+}
+
+// PolygonIntersection returns the region covered by both the subject and the clip polygons.
+func PolygonIntersection(subject, clip [][]vector.Vector2) [][]vector.Vector2 {
+	return ClipPolygons(subject, clip, Intersection)
+}
+
+// PolygonDifference returns the region covered by the subject polygons but not by the clip polygons.
+func PolygonDifference(subject, clip [][]vector.Vector2) [][]vector.Vector2 {
+	return ClipPolygons(subject, clip, Difference)
+}
+
+// PolygonXor returns the region covered by either the subject or the clip polygons, but not by both.
+func PolygonXor(subject, clip [][]vector.Vector2) [][]vector.Vector2 {
+	return ClipPolygons(subject, clip, Xor)
+}
+
+// ClipPolygons applies a boolean operation between two sets of rings.
+//
+// Each set of rings describes a region using the even-odd rule, so holes are simply rings placed inside other rings,
+// in any winding order. The rings may intersect each other and themselves.
+// Returns the rings of the resulting region, where the outer rings are Clockwise and the holes are CounterClockwise.
+// Rings touching each other at a vertex are returned separately.
+func ClipPolygons(subject, clip [][]vector.Vector2, operation BooleanOperation) [][]vector.Vector2 {
+	o := newOverlay([][][]vector.Vector2{subject, clip})
+	return removeColinearRings(o.trace(operation.inside))
+}
+
+// inside determines whether a point is inside the result of the operation, given its winding numbers around the
+// subject and the clip rings
+func (operation BooleanOperation) inside(winding []int) bool {
+	inSubject := winding[0]%2 != 0
+	inClip := winding[1]%2 != 0
+
+	switch operation {
+	case Union:
+		return inSubject || inClip
+	case Intersection:
+		return inSubject && inClip
+	case Difference:
+		return inSubject && !inClip
+	default:
+		return inSubject != inClip
+	}
+}
+
+// removeColinearRings removes the collinear vertices of the traced rings, dropping the rings left with less than
+// three vertices
+func removeColinearRings(rings [][]vector.Vector2) (result [][]vector.Vector2) {
+	for _, ring := range rings {
+		if ring = RemoveColinearVertices(ring, 0); len(ring) >= 3 {
+			result = append(result, ring)
+		}
+	}
+	return
+}
+
+// overlay is the planar graph formed by the edges of several sets of rings, split at their intersections.
+//
+// Each edge goes from its vertex with the lowest index to the one with the highest index, and holds, for each set
+// of rings, how many times it is traversed in that direction minus how many times it is traversed backwards.
+type overlay struct {
+	vertices []vector.Vector2
+	edges    [][2]int
+	counts   [][]int
+	rows     bands // edges indexed by their Y range
+	columns  bands // edges indexed by their X range
+}
+
+// newOverlay builds the overlay of the given sets of rings
+func newOverlay(sets [][][]vector.Vector2) *overlay {
+	var segments []sweepline.Segment
+	var owners []int
+	for set, rings := range sets {
+		for _, ring := range rings {
+			for i := range ring {
+				a := ring[i]
+				b := ring[(i+1)%len(ring)]
+				if a != b {
+					segments = append(segments, sweepline.NewSegment(a, b))
+					owners = append(owners, set)
+				}
+			}
+		}
+	}
+
+	// every segment is split at the points where it meets other segments
+	splits := make([][]vector.Vector2, len(segments))
+	intersections := sweepline.FindSegmentIntersections(segments, sweepline.Options{
+		Endpoints: sweepline.IgnoreSharedEndpoints,
+	})
+	for _, intersection := range intersections {
+		for _, s := range intersection.Segments {
+			splits[s] = append(splits[s], intersection.Point)
+			if intersection.IsOverlap() {
+				splits[s] = append(splits[s], intersection.End)
+			}
+		}
+	}
+
+	o := &overlay{}
+	indices := make(map[vector.Vector2]int)
+	edges := make(map[[2]int]int)
+	vertex := func(p vector.Vector2) int {
+		i, ok := indices[p]
+		if !ok {
+			i = len(o.vertices)
+			indices[p] = i
+			o.vertices = append(o.vertices, p)
+		}
+		return i
+	}
+
+	for s, segment := range segments {
+		points := append([]vector.Vector2{segment.A, segment.B}, splits[s]...)
+		direction := segment.A.To(segment.B)
+		sort.Slice(points, func(i, j int) bool {
+			return segment.A.To(points[i]).Dot(direction) < segment.A.To(points[j]).Dot(direction)
+		})
+
+		for i := 1; i < len(points); i++ {
+			a := vertex(points[i-1])
+			b := vertex(points[i])
+			if a == b {
+				continue
+			}
+
+			sign := 1
+			if a > b {
+				a, b = b, a
+				sign = -1
+			}
+			e, ok := edges[[2]int{a, b}]
+			if !ok {
+				e = len(o.edges)
+				edges[[2]int{a, b}] = e
+				o.edges = append(o.edges, [2]int{a, b})
+				o.counts = append(o.counts, make([]int, len(sets)))
+			}
+			o.counts[e][owners[s]] += sign
+		}
+	}
+
+	o.rows = newBands(len(o.edges), func(e int) (float64, float64) {
+		return o.vertices[o.edges[e][0]].Y, o.vertices[o.edges[e][1]].Y
+	})
+	o.columns = newBands(len(o.edges), func(e int) (float64, float64) {
+		return o.vertices[o.edges[e][0]].X, o.vertices[o.edges[e][1]].X
+	})
+	return o
+}
+
+// sides returns the winding numbers of each set of rings on the left and on the right of the edge e
+func (o *overlay) sides(e int) (left, right []int) {
+	a := o.vertices[o.edges[e][0]]
+	b := o.vertices[o.edges[e][1]]
+	m := vector.Lerp(a, b, 0.5)
+	sets := len(o.counts[e])
+
+	// the winding numbers are calculated just after the midpoint, along a ray which crosses the edge. The ray
+	// follows the axis along which the edge spreads the least, as the rounded midpoint may lie slightly off the
+	// edge, and a ray nearly parallel to the edge would then start on the wrong side of it
+	plus := make([]int, sets)
+	minus := make([]int, sets)
+	horizontal := math.Abs(b.X-a.X) > math.Abs(b.Y-a.Y)
+	if horizontal {
+		o.columns.query(m.X, func(other int) {
+			if other != e {
+				o.crossUp(other, m, plus)
+			}
+		})
+	} else {
+		o.rows.query(m.Y, func(other int) {
+			if other != e {
+				o.crossRight(other, m, plus)
+			}
+		})
+	}
+
+	// crossing the edge itself changes the winding numbers by its counts
+	for set, count := range o.counts[e] {
+		minus[set] = plus[set]
+		switch {
+		case horizontal && a.X > b.X, !horizontal && a.Y < b.Y:
+			minus[set] += count
+		default:
+			minus[set] -= count
+		}
+	}
+
+	// the ray points to the right of upward and leftward edges
+	if (horizontal && a.X > b.X) || (!horizontal && a.Y < b.Y) {
+		return minus, plus
+	}
+	return plus, minus
+}
+
+// crossRight adds the winding contribution of the edge e to a ray cast from the point p towards +X
+func (o *overlay) crossRight(e int, p vector.Vector2, winding []int) {
+	a := o.vertices[o.edges[e][0]]
+	b := o.vertices[o.edges[e][1]]
+	if (a.Y > p.Y) == (b.Y > p.Y) {
+		return
+	}
+
+	// an upward edge passing to the right of p winds counter-clockwise around it
+	orientation := predicates.Orient2D(a, b, p)
+	for set, count := range o.counts[e] {
+		if a.Y < b.Y && orientation > 0 {
+			winding[set] += count
+		} else if a.Y > b.Y && orientation < 0 {
+			winding[set] -= count
+		}
+	}
+}
+
+// crossUp adds the winding contribution of the edge e to a ray cast from the point p towards +Y
+func (o *overlay) crossUp(e int, p vector.Vector2, winding []int) {
+	a := o.vertices[o.edges[e][0]]
+	b := o.vertices[o.edges[e][1]]
+	if (a.X > p.X) == (b.X > p.X) {
+		return
+	}
+
+	// a leftward edge passing above p winds counter-clockwise around it
+	orientation := predicates.Orient2D(a, b, p)
+	for set, count := range o.counts[e] {
+		if a.X > b.X && orientation > 0 {
+			winding[set] += count
+		} else if a.X < b.X && orientation < 0 {
+			winding[set] -= count
+		}
+	}
+}
+
+// trace keeps the edges separating a region which is inside, according to the given function, from a region
+// which is not, and joins them into rings with the inside on their right. The collinear vertices are kept.
+func (o *overlay) trace(inside func(winding []int) bool) (rings [][]vector.Vector2) {
+	outgoing := make([][]int, len(o.vertices))
+	var targets []int
+	for e, edge := range o.edges {
+		left, right := o.sides(e)
+		inLeft := inside(left)
+		inRight := inside(right)
+		if inLeft == inRight {
+			continue
+		}
+
+		from, to := edge[0], edge[1]
+		if inLeft {
+			from, to = to, from
+		}
+		outgoing[from] = append(outgoing[from], len(targets))
+		targets = append(targets, to)
+	}
+
+	used := make([]bool, len(targets))
+	for from := range outgoing {
+		for _, start := range outgoing[from] {
+			if used[start] {
+				continue
+			}
+
+			var ring []vector.Vector2
+			previous := from
+			for e := start; e != -1; {
+				used[e] = true
+				current := targets[e]
+				ring = append(ring, o.vertices[previous])
+				if current == from {
+					break
+				}
+				e = o.nextEdge(outgoing[current], targets, used, previous, current)
+				previous = current
+			}
+			rings = append(rings, ring)
+		}
+	}
+	return
+}
+
+// nextEdge chooses, among the unused outgoing edges of the current vertex, the one making the sharpest turn to
+// the right, so that rings touching at a vertex are kept apart.
+// Returns -1 if there is no unused outgoing edge.
+func (o *overlay) nextEdge(outgoing, targets []int, used []bool, previous, current int) int {
+	origin := o.vertices[current]
+	back := origin.To(o.vertices[previous])
+	backAngle := math.Atan2(back.Y, back.X)
+
+	next := -1
+	bestAngle := math.Inf(1)
+	for _, e := range outgoing {
+		if used[e] {
+			continue
+		}
+
+		// the counter-clockwise angle from the incoming edge, reversed, to the outgoing edge
+		d := origin.To(o.vertices[targets[e]])
+		angle := math.Atan2(d.Y, d.X) - backAngle
+		for angle <= 0 {
+			angle += 2 * math.Pi
+		}
+		if angle < bestAngle {
+			bestAngle = angle
+			next = e
+		}
+	}
+	return next
+}
+
+// bands indexes intervals by splitting their range into buckets of the same size.
+type bands struct {
+	min     float64
+	size    float64
+	buckets [][]int
+}
+
+// newBands indexes n intervals, whose bounds are given by the interval function
+func newBands(n int, interval func(i int) (float64, float64)) (b bands) {
+	if n == 0 {
+		return
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for i := 0; i < n; i++ {
+		lo, hi := interval(i)
+		min = math.Min(min, math.Min(lo, hi))
+		max = math.Max(max, math.Max(lo, hi))
+	}
+
+	count := int(math.Sqrt(float64(n))) + 1
+	b.min = min
+	b.size = (max - min) / float64(count)
+	b.buckets = make([][]int, count)
+	for i := 0; i < n; i++ {
+		lo, hi := interval(i)
+		first, last := b.bucket(math.Min(lo, hi)), b.bucket(math.Max(lo, hi))
+		for j := first; j <= last; j++ {
+			b.buckets[j] = append(b.buckets[j], i)
+		}
+	}
+	return
+}
+
+// bucket returns the bucket holding the value
+func (b bands) bucket(value float64) int {
+	if b.size == 0 {
+		return 0
+	}
+	i := int((value - b.min) / b.size)
+	if i < 0 {
+		return 0
+	}
+	if i >= len(b.buckets) {
+		return len(b.buckets) - 1
+	}
+	return i
+}
+
+// query calls the function for every interval which may contain the value
+func (b bands) query(value float64, f func(i int)) {
+	if len(b.buckets) == 0 {
+		return
+	}
+	for _, i := range b.buckets[b.bucket(value)] {
+		f(i)
+	}
+}
@@ -0,0 +1,154 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// PointLocation defines an enumerator representing where a point lies relative to a polygon.
+type PointLocation byte
+
+const (
+	Outside PointLocation = iota
+	Inside
+	OnEdge
+	OnVertex
+)
+
+// ContainsPoint determines whether the point p is inside the polygon, according to the fill rule.
+// The points lying on the boundary are considered inside.
+func ContainsPoint(vertices []vector.Vector2, p vector.Vector2, rule FillRule) bool {
+	return LocatePoint(vertices, p, rule, 0) != Outside
+}
+
+// LocatePoint classifies the point p as inside, outside or on the boundary of the polygon.
+//
+// Receives the vertices of the polygon, in any winding order, the point, the fill rule and the tolerance.
+// The point lies on a vertex or on an edge if it is closer to it than the tolerance, or exactly on it when the
+// tolerance is zero. Otherwise, it is inside or outside according to the fill rule.
+func LocatePoint(vertices []vector.Vector2, p vector.Vector2, rule FillRule, tolerance float64) PointLocation {
+	return locatePoint(vertices, p, rule, tolerance, func(f func(i int)) {
+		for i := range vertices {
+			f(i)
+		}
+	})
+}
+
+// PointLocator holds a polygon split into horizontal slabs, so that each point query only visits the edges of the
+// slab holding the point. It is meant for testing many points against the same polygon.
+type PointLocator struct {
+	vertices  []vector.Vector2
+	rule      FillRule
+	tolerance float64
+	min       vector.Vector2
+	max       vector.Vector2
+	slabs     bands
+}
+
+// NewPointLocator pre-processes a copy of the polygon for the point queries, which use the fill rule and the tolerance.
+func NewPointLocator(vertices []vector.Vector2, rule FillRule, tolerance float64) *PointLocator {
+	l := &PointLocator{
+		vertices:  append([]vector.Vector2{}, vertices...),
+		rule:      rule,
+		tolerance: tolerance,
+	}
+	if len(vertices) == 0 {
+		return l
+	}
+
+	l.min, l.max = vertices[0], vertices[0]
+	for _, v := range vertices {
+		l.min = vector.Vector2{X: math.Min(l.min.X, v.X), Y: math.Min(l.min.Y, v.Y)}
+		l.max = vector.Vector2{X: math.Max(l.max.X, v.X), Y: math.Max(l.max.Y, v.Y)}
+	}
+
+	// the edges are widened by the tolerance, so that the points close to them are found in their slabs
+	l.slabs = newBands(len(vertices), func(i int) (float64, float64) {
+		a := l.vertices[i]
+		b := l.vertices[(i+1)%len(l.vertices)]
+		return math.Min(a.Y, b.Y) - tolerance, math.Max(a.Y, b.Y) + tolerance
+	})
+	return l
+}
+
+// LocatePoint classifies the point p as inside, outside or on the boundary of the polygon.
+func (l *PointLocator) LocatePoint(p vector.Vector2) PointLocation {
+	if len(l.vertices) == 0 || p.X < l.min.X-l.tolerance || p.X > l.max.X+l.tolerance ||
+		p.Y < l.min.Y-l.tolerance || p.Y > l.max.Y+l.tolerance {
+		return Outside
+	}
+	return locatePoint(l.vertices, p, l.rule, l.tolerance, func(f func(i int)) {
+		l.slabs.query(p.Y, f)
+	})
+}
+
+// LocatePoints classifies each of the points as inside, outside or on the boundary of the polygon.
+func (l *PointLocator) LocatePoints(points []vector.Vector2) []PointLocation {
+	locations := make([]PointLocation, len(points))
+	for i, p := range points {
+		locations[i] = l.LocatePoint(p)
+	}
+	return locations
+}
+
+// ContainsPoint determines whether the point p is inside the polygon or on its boundary.
+func (l *PointLocator) ContainsPoint(p vector.Vector2) bool {
+	return l.LocatePoint(p) != Outside
+}
+
+// locatePoint classifies the point p against the edges given by the edges function, which must include every edge
+// crossing the horizontal line through p or closer to p than the tolerance
+func locatePoint(vertices []vector.Vector2, p vector.Vector2, rule FillRule, tolerance float64, edges func(f func(i int))) PointLocation {
+	var winding int
+	var onVertex, onEdge bool
+	edges(func(i int) {
+		if onVertex {
+			return
+		}
+		a := vertices[i]
+		b := vertices[(i+1)%len(vertices)]
+		if a == p || b == p || (tolerance > 0 && (a.Distance(p) <= tolerance || b.Distance(p) <= tolerance)) {
+			onVertex = true
+			return
+		}
+
+		orientation := predicates.Orient2D(a, b, p)
+		if orientation == 0 && p.X >= math.Min(a.X, b.X) && p.X <= math.Max(a.X, b.X) &&
+			p.Y >= math.Min(a.Y, b.Y) && p.Y <= math.Max(a.Y, b.Y) {
+			onEdge = true
+		} else if tolerance > 0 && distanceToSegment(p, a, b) <= tolerance {
+			onEdge = true
+		}
+
+		// the edges include their lower endpoint only, so the vertices on the ray are counted once
+		if a.Y <= p.Y {
+			if b.Y > p.Y && orientation > 0 {
+				winding++
+			}
+		} else if b.Y <= p.Y && orientation < 0 {
+			winding--
+		}
+	})
+
+	switch {
+	case onVertex:
+		return OnVertex
+	case onEdge:
+		return OnEdge
+	case rule == NonZero && winding != 0, rule == EvenOdd && winding%2 != 0:
+		return Inside
+	}
+	return Outside
+}
+
+// distanceToSegment returns the distance between the point p and the closest point of the segment ab
+func distanceToSegment(p, a, b vector.Vector2) float64 {
+	ab := a.To(b)
+	t := a.To(p).Dot(ab) / ab.MagnitudeSqr()
+	if math.IsNaN(t) {
+		return p.Distance(a)
+	}
+	return p.Distance(a.Add(ab.Mul(math.Max(0, math.Min(1, t)))))
+}
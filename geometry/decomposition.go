@@ -0,0 +1,454 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	"github.com/mindera-gaming/go-math/geometry/sweepline"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// MergeTriangles merges a triangulation into convex polygons, using the Hertel–Mehlhorn algorithm.
+//
+// Receives the vertices and the indices of the triangles, such as the output of earclipping.Triangulate or
+// earclipping.TriangulateWithHoles, so polygons with holes are supported.
+// Every diagonal shared by two polygons is removed if the polygon formed by joining them is still convex,
+// which yields at most four times the minimum number of convex polygons.
+// Returns the indices of the vertices of each convex polygon, in clockwise order.
+func MergeTriangles(vertices []vector.Vector2, triangles []int) (polygons [][]int, err error) {
+	if len(triangles)%3 != 0 {
+		err = ErrInvalidTriangleIndices
+		return
+	}
+	for _, i := range triangles {
+		if i < 0 || i >= len(vertices) {
+			err = ErrInvalidTriangleIndices
+			return
+		}
+	}
+
+	pieces := make([][]int, 0, len(triangles)/3)
+	for t := 0; t < len(triangles); t += 3 {
+		a, b, c := triangles[t], triangles[t+1], triangles[t+2]
+		switch orientation := predicates.Orient2D(vertices[a], vertices[b], vertices[c]); {
+		case orientation < 0:
+			pieces = append(pieces, []int{a, b, c})
+		case orientation > 0:
+			pieces = append(pieces, []int{a, c, b})
+		}
+	}
+	return mergeConvexPieces(vertices, pieces)
+}
+
+// DecomposeConvex splits a simple polygon into convex polygons, without adding new vertices.
+//
+// Receives the vertices of the polygon, in any winding order.
+// Following Bayazit's approach, every reflex vertex is joined to a visible vertex, preferring the ones that make it
+// convex on both sides, then the reflex ones, which are made convex as well, and then the closest ones.
+// The diagonals which are not needed to keep the polygons convex are removed afterwards.
+// Returns the indices of the vertices of each convex polygon, in clockwise order.
+func DecomposeConvex(vertices []vector.Vector2) (polygons [][]int, err error) {
+	return DecomposeConvexWithHoles(vertices, nil)
+}
+
+// DecomposeConvexWithHoles splits a simple polygon with holes into convex polygons, without adding new vertices.
+//
+// Receives the vertices of the outer polygon and the vertices of each hole, in any winding order.
+// Each hole, from right to left, is merged into the outer boundary through a pair of bridge edges connecting its
+// rightmost vertex to the closest visible vertex of the boundary, and the resulting polygon is decomposed as in
+// DecomposeConvex. The bridges are then removed like any other diagonal which is not needed.
+// Returns the indices of the vertices of each convex polygon, in clockwise order. The indices refer to the
+// concatenation of the outer vertices followed by the vertices of each hole, in the given order.
+func DecomposeConvexWithHoles(outer []vector.Vector2, holes [][]vector.Vector2) (polygons [][]int, err error) {
+	_, order := ComputePolygonArea(outer)
+	if order == Invalid || !IsSimplePolygon(outer) {
+		err = ErrComplexPolygon
+		return
+	}
+	holeOrders := make([]WindingOrder, len(holes))
+	for i, hole := range holes {
+		if _, holeOrders[i] = ComputePolygonArea(hole); holeOrders[i] == Invalid || !IsSimplePolygon(hole) {
+			err = ErrComplexPolygon
+			return
+		}
+	}
+	if !areHolesInside(outer, holes) {
+		err = ErrInvalidHoles
+		return
+	}
+
+	// concatenating every ring into a single vertex array, with the outer boundary in clockwise order
+	vertices := append([]vector.Vector2{}, outer...)
+	polygon := make([]int, len(outer))
+	for i := range polygon {
+		polygon[i] = i
+		if order == CounterClockwise {
+			polygon[i] = len(outer) - 1 - i
+		}
+	}
+	rings := make([][]int, len(holes))
+	for h, hole := range holes {
+		rings[h] = make([]int, len(hole))
+		for i := range hole {
+			rings[h][i] = len(vertices) + i
+			if holeOrders[h] == Clockwise {
+				rings[h][i] = len(vertices) + len(hole) - 1 - i
+			}
+		}
+		vertices = append(vertices, hole...)
+	}
+	if polygon, err = bridgeHoles(vertices, polygon, rings); err != nil {
+		return
+	}
+
+	pending := [][]int{polygon}
+	var pieces [][]int
+	for len(pending) != 0 {
+		piece := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		var i, j int
+		if i, j, err = findSplit(vertices, piece); err != nil {
+			return
+		}
+		if i < 0 {
+			pieces = append(pieces, piece)
+			continue
+		}
+
+		// the piece is split along the diagonal between its positions i and j
+		var first, second []int
+		for k := i; k != j; k = (k + 1) % len(piece) {
+			first = append(first, piece[k])
+		}
+		first = append(first, piece[j])
+		for k := j; k != i; k = (k + 1) % len(piece) {
+			second = append(second, piece[k])
+		}
+		second = append(second, piece[i])
+		pending = append(pending, first, second)
+	}
+	return mergeConvexPieces(vertices, pieces)
+}
+
+// areHolesInside determines whether every hole lies inside the outer polygon and outside the other holes,
+// without touching any of them
+func areHolesInside(outer []vector.Vector2, holes [][]vector.Vector2) bool {
+	if len(holes) == 0 {
+		return true
+	}
+
+	var segments []sweepline.Segment
+	var owners []int
+	for r, ring := range append([][]vector.Vector2{outer}, holes...) {
+		for i := range ring {
+			segments = append(segments, sweepline.NewSegment(ring[i], ring[(i+1)%len(ring)]))
+			owners = append(owners, r)
+		}
+	}
+	for _, intersection := range sweepline.FindSegmentIntersections(segments, sweepline.Options{}) {
+		for _, s := range intersection.Segments[1:] {
+			if owners[s] != owners[intersection.Segments[0]] {
+				return false
+			}
+		}
+	}
+
+	for i, hole := range holes {
+		if !ContainsPoint(outer, hole[0], EvenOdd) {
+			return false
+		}
+		for j := 0; j < i; j++ {
+			if ContainsPoint(holes[j], hole[0], EvenOdd) || ContainsPoint(hole, holes[j][0], EvenOdd) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// bridgeHoles merges the counter-clockwise holes into the clockwise polygon, from right to left, so that a bridge
+// never crosses a hole yet to be merged. Each hole is entered and left through its rightmost vertex, joined to the
+// closest vertex of the polygon which it can see.
+// Returns the merged polygon, whose bridge vertices appear twice
+func bridgeHoles(vertices []vector.Vector2, polygon []int, holes [][]int) ([]int, error) {
+	rightmost := make([]int, len(holes))
+	sorted := make([]int, len(holes))
+	for h, hole := range holes {
+		sorted[h] = h
+		for k := range hole {
+			if lessPoint(vertices[hole[rightmost[h]]], vertices[hole[k]]) {
+				rightmost[h] = k
+			}
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return lessPoint(vertices[holes[sorted[j]][rightmost[sorted[j]]]], vertices[holes[sorted[i]][rightmost[sorted[i]]]])
+	})
+
+	for s, h := range sorted {
+		hole := holes[h]
+		m := vertices[hole[rightmost[h]]]
+		previous := vertices[hole[(rightmost[h]-1+len(hole))%len(hole)]]
+		next := vertices[hole[(rightmost[h]+1)%len(hole)]]
+
+		// the bridge must not cross the polygon nor the holes yet to be merged
+		blocked := func(a, b vector.Vector2) bool {
+			if crossesRing(vertices, polygon, a, b) {
+				return true
+			}
+			for _, other := range sorted[s:] {
+				if crossesRing(vertices, holes[other], a, b) {
+					return true
+				}
+			}
+			return false
+		}
+
+		best := -1
+		bestDistance := math.Inf(1)
+		n := len(polygon)
+		for k := range polygon {
+			p := vertices[polygon[k]]
+			distance := m.DistanceSqr(p)
+			if distance >= bestDistance ||
+				!isInInteriorAngle(vertices[polygon[(k-1+n)%n]], p, vertices[polygon[(k+1)%n]], m) ||
+				// the interior of the polygon lies outside the counter-clockwise hole, on its right
+				!isInInteriorAngle(previous, m, next, p) || blocked(m, p) {
+				continue
+			}
+			best = k
+			bestDistance = distance
+		}
+		if best < 0 {
+			return nil, ErrNoDiagonalFound
+		}
+
+		merged := make([]int, 0, n+len(hole)+2)
+		merged = append(merged, polygon[:best+1]...)
+		for k := 0; k <= len(hole); k++ {
+			merged = append(merged, hole[(rightmost[h]+k)%len(hole)])
+		}
+		merged = append(merged, polygon[best:]...)
+		polygon = merged
+	}
+	return polygon, nil
+}
+
+// crossesRing determines whether the segment ab crosses an edge of the ring, or overlaps it, besides touching it
+// at either of its ends
+func crossesRing(vertices []vector.Vector2, ring []int, a, b vector.Vector2) bool {
+	for k := range ring {
+		c, d := vertices[ring[k]], vertices[ring[(k+1)%len(ring)]]
+		if touchesOnlyAtEnd(a, b, c, d) {
+			continue
+		}
+		if segmentsIntersect(a, b, c, d) {
+			return true
+		}
+	}
+	return false
+}
+
+// touchesOnlyAtEnd determines whether the segments ab and cd share an end without overlapping.
+// Segments sharing no end are reported as false as well
+func touchesOnlyAtEnd(a, b, c, d vector.Vector2) bool {
+	shared := 0
+	for _, p := range []vector.Vector2{c, d} {
+		if p == a || p == b {
+			shared++
+			continue
+		}
+		if predicates.Orient2D(a, b, p) == 0 && inBox(a, b, p) {
+			// the other end of the edge lies on the segment
+			return false
+		}
+	}
+	if shared == 2 {
+		// the edge lies on the segment, unless it joins its ends the other way around, which is the same edge
+		return false
+	}
+	return shared == 1
+}
+
+// findSplit finds a diagonal of the clockwise piece starting at its first reflex vertex which has one.
+// Returns the positions of both ends of the diagonal in the piece, or -1 if the piece is convex, or an error if
+// no reflex vertex has a diagonal.
+func findSplit(vertices []vector.Vector2, piece []int) (int, int, error) {
+	n := len(piece)
+	point := func(k int) vector.Vector2 {
+		return vertices[piece[(k+n)%n]]
+	}
+	isReflex := func(k int) bool {
+		return predicates.Orient2D(point(k-1), point(k), point(k+1)) > 0
+	}
+
+	convex := true
+	for i := 0; i < n; i++ {
+		if !isReflex(i) {
+			continue
+		}
+		convex = false
+
+		best := -1
+		bestScore := -1
+		bestDistance := 0.0
+		for j := 0; j < n; j++ {
+			if j == i || j == (i+1)%n || j == (i-1+n)%n || !isDiagonal(vertices, piece, i, j) {
+				continue
+			}
+
+			// the diagonal makes the vertex i convex on both sides if it lies within the opposite angle
+			score := 0
+			if predicates.Orient2D(point(i-1), point(i), point(j)) <= 0 && predicates.Orient2D(point(j), point(i), point(i+1)) <= 0 {
+				score += 2
+			}
+			if isReflex(j) {
+				score++
+			}
+			distance := point(i).DistanceSqr(point(j))
+			if score > bestScore || (score == bestScore && distance < bestDistance) {
+				best = j
+				bestScore = score
+				bestDistance = distance
+			}
+		}
+		if best >= 0 {
+			return i, best, nil
+		}
+	}
+	if convex {
+		return -1, -1, nil
+	}
+	return -1, -1, ErrNoDiagonalFound
+}
+
+// isDiagonal determines whether the segment between the positions i and j of the clockwise piece lies inside it
+// without touching its boundary. The same vertex may appear at several positions of pieces with bridged holes
+func isDiagonal(vertices []vector.Vector2, piece []int, i, j int) bool {
+	n := len(piece)
+	point := func(k int) vector.Vector2 {
+		return vertices[piece[(k+n)%n]]
+	}
+	if !isInInteriorAngle(point(i-1), point(i), point(i+1), point(j)) ||
+		!isInInteriorAngle(point(j-1), point(j), point(j+1), point(i)) {
+		return false
+	}
+
+	a, b := point(i), point(j)
+	for k := 0; k < n; k++ {
+		next := (k + 1) % n
+		if k == i || k == j || next == i || next == j {
+			continue
+		}
+		c, d := point(k), point(next)
+		if touchesOnlyAtEnd(a, b, c, d) {
+			continue
+		}
+		if segmentsIntersect(a, b, c, d) {
+			return false
+		}
+	}
+
+	// the edges sharing an end with the diagonal may still overlap it
+	for _, k := range []int{i, j} {
+		for _, other := range []int{k - 1, k + 1} {
+			if end := point(other); predicates.Orient2D(a, b, end) == 0 && inBox(a, b, end) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isInInteriorAngle determines whether the point p lies strictly within the interior angle at the vertex of a
+// clockwise polygon, where the previous and next vertices are its neighbours
+func isInInteriorAngle(previous, vertex, next, p vector.Vector2) bool {
+	rightOfIncoming := predicates.Orient2D(previous, vertex, p) < 0
+	rightOfOutgoing := predicates.Orient2D(vertex, next, p) < 0
+	if predicates.Orient2D(previous, vertex, next) <= 0 {
+		return rightOfIncoming && rightOfOutgoing
+	}
+	return rightOfIncoming || rightOfOutgoing
+}
+
+// mergeConvexPieces removes the diagonals shared by two clockwise convex pieces whenever their union is convex.
+// Returns the merged pieces.
+func mergeConvexPieces(vertices []vector.Vector2, pieces [][]int) ([][]int, error) {
+	// every directed edge belongs to a single piece
+	owners := make(map[[2]int]int)
+	for p, piece := range pieces {
+		for k := range piece {
+			edge := [2]int{piece[k], piece[(k+1)%len(piece)]}
+			if _, ok := owners[edge]; ok {
+				return nil, ErrOverlappingTriangles
+			}
+			owners[edge] = p
+		}
+	}
+
+	for p := 0; p < len(pieces); p++ {
+		for k := 0; pieces[p] != nil && k < len(pieces[p]); k++ {
+			piece := pieces[p]
+			u, v := piece[k], piece[(k+1)%len(piece)]
+			q, ok := owners[[2]int{v, u}]
+			if !ok || q == p {
+				continue
+			}
+
+			merged := joinPieces(piece, pieces[q], k)
+			if !isConvexPiece(vertices, merged) {
+				continue
+			}
+
+			pieces[p] = merged
+			pieces[q] = nil
+			delete(owners, [2]int{u, v})
+			delete(owners, [2]int{v, u})
+			for m := range merged {
+				owners[[2]int{merged[m], merged[(m+1)%len(merged)]}] = p
+			}
+			k = -1
+		}
+	}
+
+	var polygons [][]int
+	for _, piece := range pieces {
+		if piece != nil {
+			polygons = append(polygons, piece)
+		}
+	}
+	return polygons, nil
+}
+
+// joinPieces returns the piece formed by two pieces sharing the edge which starts at the position k of the first
+func joinPieces(first, second []int, k int) []int {
+	u := first[k]
+	joined := make([]int, 0, len(first)+len(second)-2)
+
+	// the first piece from v around to u, then the second one from u around to v, excluding both ends
+	for m := 1; m <= len(first); m++ {
+		joined = append(joined, first[(k+m)%len(first)])
+	}
+	start := 0
+	for second[start] != u {
+		start++
+	}
+	for m := 1; m < len(second)-1; m++ {
+		joined = append(joined, second[(start+m)%len(second)])
+	}
+	return joined
+}
+
+// isConvexPiece determines whether no vertex of the clockwise piece turns left
+func isConvexPiece(vertices []vector.Vector2, piece []int) bool {
+	for k := range piece {
+		previous := vertices[piece[(k-1+len(piece))%len(piece)]]
+		next := vertices[piece[(k+1)%len(piece)]]
+		if predicates.Orient2D(previous, vertices[piece[k]], next) > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,11 @@
+package geometry
+
+import "errors"
+
+var (
+	ErrComplexPolygon         = errors.New("The vertex list does not define a simple polygon with an area.")
+	ErrInvalidTriangleIndices = errors.New("The index list does not describe triangles of the vertex list.")
+	ErrOverlappingTriangles   = errors.New("The triangles overlap each other.")
+	ErrInvalidHoles           = errors.New("A hole is not strictly inside the outer polygon or overlaps another hole.")
+	ErrNoDiagonalFound        = errors.New("The polygon could not be fully decomposed.")
+)
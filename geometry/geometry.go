@@ -3,6 +3,7 @@ package geometry
 import (
 	"math"
 
+	"github.com/mindera-gaming/go-math/geometry/predicates"
 	"github.com/mindera-gaming/go-math/geometry/sweepline"
 	vector "github.com/mindera-gaming/go-math/vector2"
 )
@@ -13,15 +14,17 @@ func IsSimplePolygon(vertices []vector.Vector2) bool {
 		return false
 	}
 
-	// firstly it will check for overlapping vertices
-	for i := 1; i < len(vertices); i++ {
-		if vertices[i] == vertices[i-1] {
+	// firstly it will check for repeated vertices
+	visited := make(map[vector.Vector2]bool, len(vertices))
+	for _, v := range vertices {
+		if visited[v] {
 			// there is an overlap
 			return false
 		}
+		visited[v] = true
 	}
 
-	// finding intersections using the sweep-line algorithm
+	// finding intersections using the sweep-line algorithm, the vertices shared by consecutive edges being ignored
 	segments := make([]sweepline.Segment, len(vertices))
 	for i := 1; i < len(vertices); i++ {
 		segments[i-1] = sweepline.NewSegment(vertices[i-1], vertices[i])
@@ -29,7 +32,10 @@ func IsSimplePolygon(vertices []vector.Vector2) bool {
 	lastIndex := len(vertices) - 1
 	segments[lastIndex] = sweepline.NewSegment(vertices[lastIndex], vertices[0])
 
-	intersections := sweepline.FindIntersections(segments)
+	intersections := sweepline.FindSegmentIntersections(segments, sweepline.Options{
+		StopAtFirst: true,
+		Endpoints:   sweepline.IgnoreSharedEndpoints,
+	})
 
 	return len(intersections) <= 0
 }
@@ -40,17 +46,13 @@ func ContainsColinearEdges(vertices []vector.Vector2) bool {
 		return false
 	}
 
-	// determining if 3 points are collinear using the distance formula
+	// determining if 3 points are collinear, with the middle one between the others
 	for i := 0; i < len(vertices)-2; i++ {
 		a := vertices[i]
 		b := vertices[i+1]
 		c := vertices[i+2]
 
-		abDist := a.Distance(b)
-		cbDist := c.Distance(b)
-		acDist := a.Distance(c)
-
-		if (abDist + cbDist) == acDist {
+		if predicates.Orient2D(a, b, c) == 0 && a.To(b).Dot(b.To(c)) >= 0 {
 			return true
 		}
 	}
@@ -89,17 +91,9 @@ func ComputePolygonArea(vertices []vector.Vector2) (float64, WindingOrder) {
 
 // IsPointInTriangle verifies if the point p is inside the triangle abc.
 func IsPointInTriangle(p, a, b, c vector.Vector2) bool {
-	ab := a.To(b)
-	bc := b.To(c)
-	ca := c.To(a)
-
-	ap := a.To(p)
-	bp := b.To(p)
-	cp := c.To(p)
-
-	cross1 := ab.Cross(ap)
-	cross2 := bc.Cross(bp)
-	cross3 := ca.Cross(cp)
+	cross1 := predicates.Orient2D(a, b, p)
+	cross2 := predicates.Orient2D(b, c, p)
+	cross3 := predicates.Orient2D(c, a, p)
 
 	if cross1 > 0 || cross2 > 0 || cross3 > 0 {
 		return false
@@ -107,3 +101,25 @@ func IsPointInTriangle(p, a, b, c vector.Vector2) bool {
 
 	return true
 }
+
+// InteriorAngle returns the interior angle of the triangle abc at the vertex a, in radians.
+func InteriorAngle(a, b, c vector.Vector2) float64 {
+	u := a.To(b)
+	v := a.To(c)
+	return math.Abs(math.Atan2(u.Cross(v), u.Dot(v)))
+}
+
+// Circumcenter returns the center of the circle through the vertices of the triangle abc.
+func Circumcenter(a, b, c vector.Vector2) vector.Vector2 {
+	d := a.To(b)
+	e := a.To(c)
+
+	bl := d.MagnitudeSqr()
+	cl := e.MagnitudeSqr()
+	f := 0.5 / d.Cross(e)
+
+	return vector.Vector2{
+		X: a.X + (e.Y*bl-d.Y*cl)*f,
+		Y: a.Y + (d.X*cl-e.X*bl)*f,
+	}
+}
@@ -0,0 +1,587 @@
+package geometry
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// HullOptions is the structure that defines the hull options.
+type HullOptions struct {
+	Order        WindingOrder // winding order of the returned hull, Clockwise by default
+	KeepColinear bool         // set to true to keep the points lying on the edges of the hull
+}
+
+// ConvexHull computes the convex hull of a set of points, using Andrew's monotone chain algorithm.
+//
+// Receives the points and the hull options.
+// Returns the indices of the points on the hull, starting at the point with the lowest X (and lowest Y).
+// Repeated points appear once. If every point is collinear, the hull only holds the extreme points, along with
+// the points between them if the collinear points are kept.
+func ConvexHull(points []vector.Vector2, options HullOptions) []int {
+	sorted := sortUnique(points)
+	if hull, ok := collinearHull(points, sorted, options); ok {
+		return hull
+	}
+	return orientHull(monotoneChain(points, sorted, options.KeepColinear), options)
+}
+
+// QuickHull computes the convex hull of a set of points, using the QuickHull algorithm.
+// It is usually faster than ConvexHull when few of the points lie on the hull.
+//
+// Receives the points and the hull options.
+// Returns the indices of the points on the hull, starting at the point with the lowest X (and lowest Y).
+// Repeated points appear once. If every point is collinear, the hull only holds the extreme points, along with
+// the points between them if the collinear points are kept.
+func QuickHull(points []vector.Vector2, options HullOptions) []int {
+	sorted := sortUnique(points)
+	if hull, ok := collinearHull(points, sorted, options); ok {
+		return hull
+	}
+
+	// the hull is built counter-clockwise, from the leftmost to the rightmost point along the bottom, and back
+	first := sorted[0]
+	last := sorted[len(sorted)-1]
+	inner := sorted[1 : len(sorted)-1]
+	hull := []int{first}
+	hull = quickHull(points, first, last, outside(points, first, last, inner), options.KeepColinear, hull)
+	hull = append(hull, last)
+	hull = quickHull(points, last, first, outside(points, last, first, inner), options.KeepColinear, hull)
+	return orientHull(hull, options)
+}
+
+// quickHull appends the hull points between the points a and b, chosen among the candidates on the right of ab
+func quickHull(points []vector.Vector2, a, b int, candidates []int, keep bool, hull []int) []int {
+	farthest := -1
+	farthestOrientation := 0.0
+	for _, i := range candidates {
+		if orientation := predicates.Orient2D(points[a], points[b], points[i]); orientation < farthestOrientation {
+			farthest = i
+			farthestOrientation = orientation
+		}
+	}
+
+	if farthest < 0 {
+		// the candidates left lie on the edge ab
+		if keep {
+			direction := points[a].To(points[b])
+			sort.Slice(candidates, func(i, j int) bool {
+				return points[a].To(points[candidates[i]]).Dot(direction) < points[a].To(points[candidates[j]]).Dot(direction)
+			})
+			hull = append(hull, candidates...)
+		}
+		return hull
+	}
+
+	hull = quickHull(points, a, farthest, outside(points, a, farthest, candidates), keep, hull)
+	hull = append(hull, farthest)
+	return quickHull(points, farthest, b, outside(points, farthest, b, candidates), keep, hull)
+}
+
+// outside returns the candidates on the right of the line ab, or on the segment ab itself
+func outside(points []vector.Vector2, a, b int, candidates []int) (result []int) {
+	for _, i := range candidates {
+		if i == a || i == b {
+			continue
+		}
+		orientation := predicates.Orient2D(points[a], points[b], points[i])
+		if orientation < 0 || (orientation == 0 && points[a].To(points[i]).Dot(points[i].To(points[b])) > 0) {
+			result = append(result, i)
+		}
+	}
+	return
+}
+
+// IncrementalHull maintains the convex hull of a set of points which grows one point at a time.
+type IncrementalHull struct {
+	options   HullOptions
+	points    []vector.Vector2
+	hull      []int // counter-clockwise hull, including its collinear points
+	collinear bool  // whether every point is collinear, the hull holding them sorted
+}
+
+// NewIncrementalHull creates an empty hull, whose points are returned according to the hull options.
+func NewIncrementalHull(options HullOptions) *IncrementalHull {
+	return &IncrementalHull{options: options, collinear: true}
+}
+
+// Add adds a point to the set, updating the hull if the point lies outside of it or on its boundary.
+// Returns the index of the point, which is the number of points added before it.
+func (h *IncrementalHull) Add(p vector.Vector2) int {
+	index := len(h.points)
+	h.points = append(h.points, p)
+	if h.collinear {
+		h.addCollinear(index)
+	} else {
+		h.addOutside(index)
+	}
+	return index
+}
+
+// addCollinear adds the point to the sorted collinear points, or builds the first hull with an area from them
+func (h *IncrementalHull) addCollinear(index int) {
+	p := h.points[index]
+	if len(h.hull) < 2 || predicates.Orient2D(h.points[h.hull[0]], h.points[h.hull[len(h.hull)-1]], p) == 0 {
+		k := sort.Search(len(h.hull), func(k int) bool {
+			return !lessPoint(h.points[h.hull[k]], p)
+		})
+		if k < len(h.hull) && h.points[h.hull[k]] == p {
+			return
+		}
+		h.hull = append(h.hull, 0)
+		copy(h.hull[k+1:], h.hull[k:])
+		h.hull[k] = index
+		return
+	}
+
+	// the collinear points are walked in the direction which leaves the point on their left
+	if predicates.Orient2D(h.points[h.hull[0]], h.points[h.hull[len(h.hull)-1]], p) < 0 {
+		for i, j := 0, len(h.hull)-1; i < j; i, j = i+1, j-1 {
+			h.hull[i], h.hull[j] = h.hull[j], h.hull[i]
+		}
+	}
+	h.hull = append(h.hull, index)
+	h.collinear = false
+}
+
+// addOutside updates the hull with the point if it lies outside of it or on its boundary.
+// The edges which can see the point form a chain, whose inner points are replaced by the point
+func (h *IncrementalHull) addOutside(index int) {
+	p := h.points[index]
+	n := len(h.hull)
+	visible := func(i int) bool {
+		return predicates.Orient2D(h.points[h.hull[i%n]], h.points[h.hull[(i+1)%n]], p) < 0
+	}
+
+	first := -1
+	for i := 0; i < n; i++ {
+		if visible(i) && !visible(i+n-1) {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		// the point lies inside the hull or on one of its edges, where it is kept as a collinear point
+		for i := 0; i < n; i++ {
+			a, b := h.points[h.hull[i]], h.points[h.hull[(i+1)%n]]
+			if p == a || p == b {
+				return
+			}
+			if predicates.Orient2D(a, b, p) == 0 && inBox(a, b, p) {
+				h.hull = append(h.hull[:i+1], append([]int{index}, h.hull[i+1:]...)...)
+				return
+			}
+		}
+		return
+	}
+
+	last := first + 1
+	for visible(last) {
+		last++
+	}
+
+	// the hull restarts at the end of the chain, going around to its start and then to the point
+	hull := make([]int, 0, n-(last-first)+2)
+	for i := last; i <= first+n; i++ {
+		hull = append(hull, h.hull[i%n])
+	}
+	h.hull = append(hull, index)
+}
+
+// Len returns the number of points added to the set.
+func (h *IncrementalHull) Len() int {
+	return len(h.points)
+}
+
+// Point returns the point at index i.
+func (h *IncrementalHull) Point(i int) vector.Vector2 {
+	return h.points[i]
+}
+
+// Hull returns the indices of the points on the current hull.
+func (h *IncrementalHull) Hull() []int {
+	hull := append([]int{}, h.hull...)
+	if h.collinear {
+		if !h.options.KeepColinear && len(hull) > 2 {
+			hull = []int{hull[0], hull[len(hull)-1]}
+		}
+		return hull
+	}
+
+	// the hull starts at the point with the lowest X (and lowest Y), as the other hulls do
+	start := 0
+	for i, index := range hull {
+		if lessPoint(h.points[index], h.points[hull[start]]) {
+			start = i
+		}
+	}
+	hull = append(hull[start:], hull[:start]...)
+	if !h.options.KeepColinear {
+		hull = removeColinearIndices(h.points, hull)
+	}
+	return orientHull(hull, h.options)
+}
+
+// ConcaveHull computes a concave hull of a set of points, using the k-nearest neighbours approach
+// (Moreira and Santos).
+//
+// Receives the points, the number of neighbours considered at each step and the hull options.
+// The hull is built by walking from each hull point to one of its k nearest neighbours, so smaller values of k give
+// tighter outlines. The value of k is raised until the hull encloses every point, falling back to the convex hull.
+// Returns the indices of the points on the hull.
+func ConcaveHull(points []vector.Vector2, k int, options HullOptions) []int {
+	sorted := sortUnique(points)
+	if k < 3 {
+		k = 3
+	}
+	if len(sorted) <= 3 {
+		return ConvexHull(points, options)
+	}
+
+	grid := newPointGrid(points, sorted)
+	for ; k < len(sorted); k++ {
+		if hull, ok := concaveHull(points, sorted, grid, k); ok {
+			if !options.KeepColinear {
+				hull = removeColinearIndices(points, hull)
+			}
+			return orientHull(hull, options)
+		}
+	}
+	return ConvexHull(points, options)
+}
+
+// concaveHull attempts to build a counter-clockwise concave hull of the unique points, using k neighbours found
+// in the grid holding them.
+// Returns false if the walk gets stuck or does not enclose every point.
+func concaveHull(points []vector.Vector2, unique []int, grid *pointGrid, k int) ([]int, bool) {
+	// the walk starts at the lowest point, which is always on the hull
+	first := unique[0]
+	for _, i := range unique {
+		if points[i].Y < points[first].Y || (points[i].Y == points[first].Y && points[i].X < points[first].X) {
+			first = i
+		}
+	}
+
+	remaining := make([]bool, len(points))
+	for _, i := range unique {
+		remaining[i] = i != first
+	}
+
+	hull := []int{first}
+	current := first
+	back := vector.Left()
+	for {
+		if len(hull) == 3 {
+			// the first point may only be reached again once the hull has an area
+			remaining[first] = true
+		}
+
+		// the nearest neighbours are tried from the sharpest right turn to the sharpest left turn
+		candidates := grid.nearest(points, remaining, points[current], k)
+		angles := make(map[int]float64, len(candidates))
+		for _, c := range candidates {
+			angles[c] = turnAngle(back, points[current].To(points[c]))
+		}
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return angles[candidates[i]] < angles[candidates[j]]
+		})
+
+		next := -1
+		for _, c := range candidates {
+			if !crossesHull(points, hull, current, c, c == first) {
+				next = c
+				break
+			}
+		}
+		if next < 0 {
+			return nil, false
+		}
+		if next == first {
+			break
+		}
+
+		hull = append(hull, next)
+		remaining[next] = false
+		back = points[next].To(points[current])
+		current = next
+	}
+
+	for _, i := range unique {
+		if LocatePoint(hullPoints(points, hull), points[i], EvenOdd, 0) == Outside {
+			return nil, false
+		}
+	}
+	return hull, true
+}
+
+// pointGrid buckets a set of points into square cells, so that the neighbours of a point are found by visiting the
+// cells around it
+type pointGrid struct {
+	min           vector.Vector2
+	cellSize      float64
+	columns, rows int
+	cells         [][]int // indices of the points in each cell, row by row
+}
+
+// newPointGrid creates a grid holding the points at the indices, with about one point per cell
+func newPointGrid(points []vector.Vector2, indices []int) *pointGrid {
+	min := vector.Vector2{X: math.Inf(1), Y: math.Inf(1)}
+	max := vector.Vector2{X: math.Inf(-1), Y: math.Inf(-1)}
+	for _, i := range indices {
+		min = vector.Vector2{X: math.Min(min.X, points[i].X), Y: math.Min(min.Y, points[i].Y)}
+		max = vector.Vector2{X: math.Max(max.X, points[i].X), Y: math.Max(max.Y, points[i].Y)}
+	}
+
+	g := &pointGrid{min: min, columns: 1, rows: 1}
+	width, height := max.X-min.X, max.Y-min.Y
+	g.cellSize = math.Sqrt(width * height / float64(len(indices)))
+	if g.cellSize == 0 {
+		// the points are collinear along an axis
+		g.cellSize = math.Max(width, height) / float64(len(indices))
+	}
+	if g.cellSize > 0 {
+		g.columns = int(width/g.cellSize) + 1
+		g.rows = int(height/g.cellSize) + 1
+	}
+
+	g.cells = make([][]int, g.columns*g.rows)
+	for _, i := range indices {
+		column, row := g.coordinates(points[i])
+		g.cells[row*g.columns+column] = append(g.cells[row*g.columns+column], i)
+	}
+	return g
+}
+
+// coordinates returns the column and the row of the cell holding the point p, clamped to the grid
+func (g *pointGrid) coordinates(p vector.Vector2) (column, row int) {
+	if g.cellSize == 0 {
+		return 0, 0
+	}
+	column = int(math.Max(0, math.Min(float64(g.columns-1), math.Floor((p.X-g.min.X)/g.cellSize))))
+	row = int(math.Max(0, math.Min(float64(g.rows-1), math.Floor((p.Y-g.min.Y)/g.cellSize))))
+	return
+}
+
+// nearest returns the k remaining points closest to the point p, from the closest one, the ties being broken by
+// their indices. The rings of cells around the point are visited until no closer point can be found.
+func (g *pointGrid) nearest(points []vector.Vector2, remaining []bool, p vector.Vector2, k int) []int {
+	column, row := g.coordinates(p)
+	var found neighbourHeap
+	for r := 0; r < g.columns || r < g.rows; r++ {
+		// the points beyond the current ring lie at least r cells away from the point
+		if len(found) == k && found[0].distance < float64(r-1)*float64(r-1)*g.cellSize*g.cellSize {
+			break
+		}
+		for y := row - r; y <= row+r; y++ {
+			if y < 0 || y >= g.rows {
+				continue
+			}
+			for x := column - r; x <= column+r; x++ {
+				if x < 0 || x >= g.columns || (y != row-r && y != row+r && x != column-r && x != column+r) {
+					continue
+				}
+				for _, i := range g.cells[y*g.columns+x] {
+					if !remaining[i] {
+						continue
+					}
+					n := neighbour{index: i, distance: p.DistanceSqr(points[i])}
+					if len(found) < k {
+						heap.Push(&found, n)
+					} else if n.closerThan(found[0]) {
+						found[0] = n
+						heap.Fix(&found, 0)
+					}
+				}
+			}
+		}
+	}
+
+	result := make([]int, len(found))
+	for i := len(found) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(&found).(neighbour).index
+	}
+	return result
+}
+
+// neighbour defines a point found by a nearest neighbours search, with its squared distance to the query point
+type neighbour struct {
+	index    int
+	distance float64
+}
+
+// closerThan determines whether the neighbour comes before the other one, breaking the ties by their indices
+func (n neighbour) closerThan(other neighbour) bool {
+	return n.distance < other.distance || (n.distance == other.distance && n.index < other.index)
+}
+
+// neighbourHeap defines a max-heap of neighbours, whose root is the farthest one, implementing heap.Interface
+type neighbourHeap []neighbour
+
+func (h neighbourHeap) Len() int { return len(h) }
+
+func (h neighbourHeap) Less(i, j int) bool { return h[j].closerThan(h[i]) }
+
+func (h neighbourHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *neighbourHeap) Push(x interface{}) { *h = append(*h, x.(neighbour)) }
+
+func (h *neighbourHeap) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// turnAngle returns the counter-clockwise angle from the direction back to the direction d, in (0, 2π]
+func turnAngle(back, d vector.Vector2) float64 {
+	angle := math.Atan2(d.Y, d.X) - math.Atan2(back.Y, back.X)
+	for angle <= 0 {
+		angle += 2 * math.Pi
+	}
+	for angle > 2*math.Pi {
+		angle -= 2 * math.Pi
+	}
+	return angle
+}
+
+// crossesHull determines whether the segment from the current point to the candidate intersects the edges of the
+// hull built so far, apart from the edge ending at the current point and, when closing the hull, the first edge
+func crossesHull(points []vector.Vector2, hull []int, current, candidate int, closing bool) bool {
+	a := points[current]
+	b := points[candidate]
+	first := 0
+	if closing {
+		first = 1
+	}
+	for i := first; i < len(hull)-2; i++ {
+		if segmentsIntersect(a, b, points[hull[i]], points[hull[i+1]]) {
+			return true
+		}
+	}
+	return false
+}
+
+// segmentsIntersect determines whether the segments ab and cd share any point
+func segmentsIntersect(a, b, c, d vector.Vector2) bool {
+	d1 := predicates.Orient2D(c, d, a)
+	d2 := predicates.Orient2D(c, d, b)
+	d3 := predicates.Orient2D(a, b, c)
+	d4 := predicates.Orient2D(a, b, d)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && inBox(c, d, a)) || (d2 == 0 && inBox(c, d, b)) ||
+		(d3 == 0 && inBox(a, b, c)) || (d4 == 0 && inBox(a, b, d))
+}
+
+// inBox determines whether the point p lies in the axis-aligned box spanned by the points a and b
+func inBox(a, b, p vector.Vector2) bool {
+	return p.X >= math.Min(a.X, b.X) && p.X <= math.Max(a.X, b.X) && p.Y >= math.Min(a.Y, b.Y) && p.Y <= math.Max(a.Y, b.Y)
+}
+
+// hullPoints returns the points at the indices of the hull
+func hullPoints(points []vector.Vector2, hull []int) []vector.Vector2 {
+	result := make([]vector.Vector2, len(hull))
+	for i, index := range hull {
+		result[i] = points[index]
+	}
+	return result
+}
+
+// sortUnique returns the indices of the points sorted by X and then by Y, keeping the first index of repeated points
+func sortUnique(points []vector.Vector2) []int {
+	indices := make([]int, len(points))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(i, j int) bool {
+		return lessPoint(points[indices[i]], points[indices[j]])
+	})
+
+	unique := indices[:0]
+	for _, i := range indices {
+		if len(unique) == 0 || points[i] != points[unique[len(unique)-1]] {
+			unique = append(unique, i)
+		}
+	}
+	return unique
+}
+
+// lessPoint determines whether the point a comes before the point b, ordering by X and then by Y
+func lessPoint(a, b vector.Vector2) bool {
+	return a.X < b.X || (a.X == b.X && a.Y < b.Y)
+}
+
+// collinearHull returns the hull of the sorted unique points if they are all collinear
+func collinearHull(points []vector.Vector2, sorted []int, options HullOptions) ([]int, bool) {
+	if len(sorted) == 0 {
+		return nil, true
+	}
+
+	first := points[sorted[0]]
+	last := points[sorted[len(sorted)-1]]
+	for _, i := range sorted {
+		if predicates.Orient2D(first, last, points[i]) != 0 {
+			return nil, false
+		}
+	}
+
+	if options.KeepColinear || len(sorted) <= 2 {
+		return append([]int{}, sorted...), true
+	}
+	return []int{sorted[0], sorted[len(sorted)-1]}, true
+}
+
+// monotoneChain returns the counter-clockwise hull of the sorted unique points, which are not all collinear
+func monotoneChain(points []vector.Vector2, sorted []int, keep bool) []int {
+	hull := make([]int, 0, 2*len(sorted))
+	turnsRight := func(i int) bool {
+		orientation := predicates.Orient2D(points[hull[len(hull)-2]], points[hull[len(hull)-1]], points[i])
+		return orientation < 0 || (!keep && orientation == 0)
+	}
+
+	// lower chain
+	for _, i := range sorted {
+		for len(hull) >= 2 && turnsRight(i) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, i)
+	}
+
+	// upper chain
+	lower := len(hull) + 1
+	for j := len(sorted) - 2; j >= 0; j-- {
+		i := sorted[j]
+		for len(hull) >= lower && turnsRight(i) {
+			hull = hull[:len(hull)-1]
+		}
+		hull = append(hull, i)
+	}
+	return hull[:len(hull)-1]
+}
+
+// removeColinearIndices removes the hull points lying on the line of their neighbours
+func removeColinearIndices(points []vector.Vector2, hull []int) []int {
+	result := make([]int, 0, len(hull))
+	for i, index := range hull {
+		previous := points[hull[(i-1+len(hull))%len(hull)]]
+		next := points[hull[(i+1)%len(hull)]]
+		if predicates.Orient2D(previous, points[index], next) != 0 || previous.To(points[index]).Dot(points[index].To(next)) < 0 {
+			result = append(result, index)
+		}
+	}
+	return result
+}
+
+// orientHull returns the counter-clockwise hull in the winding order of the options, keeping its first point
+func orientHull(hull []int, options HullOptions) []int {
+	if options.Order != CounterClockwise && len(hull) > 2 {
+		for i, j := 1, len(hull)-1; i < j; i, j = i+1, j-1 {
+			hull[i], hull[j] = hull[j], hull[i]
+		}
+	}
+	return hull
+}
@@ -0,0 +1,154 @@
+package geometry
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// JoinType defines an enumerator representing how the offset edges are joined around a vertex.
+type JoinType byte
+
+const (
+	MiterJoin JoinType = iota
+	RoundJoin
+	SquareJoin
+)
+
+// OffsetOptions is the structure that defines the polygon offset options.
+type OffsetOptions struct {
+	Join         JoinType // how the offset edges are joined around the convex vertices
+	MiterLimit   float64  // maximum distance of a miter from its vertex, relative to the offset distance, zero means 2
+	ArcTolerance float64  // maximum distance between a round join and its true arc, zero means 1% of the offset distance
+}
+
+// sinTolerance is the sine of the angle below which two consecutive edges are considered collinear
+const sinTolerance = 1e-12
+
+// OffsetPolygon inflates or deflates a polygon by a distance.
+//
+// Receives the vertices of the polygon, in any winding order, the signed offset distance and the offset options.
+// A positive distance inflates the polygon and a negative one deflates it.
+// The offset edges are joined around the vertices, and the loops they form are removed, so deflating may split the
+// polygon or make it vanish.
+// Returns the rings of the resulting region, where the outer rings are Clockwise and the holes are CounterClockwise.
+func OffsetPolygon(vertices []vector.Vector2, distance float64, options OffsetOptions) [][]vector.Vector2 {
+	// removing the repeated consecutive vertices
+	ring := make([]vector.Vector2, 0, len(vertices))
+	for i, v := range vertices {
+		if v != vertices[(i+1)%len(vertices)] {
+			ring = append(ring, v)
+		}
+	}
+
+	// the polygon is offset in clockwise order, so its outward normals lie on the left of its edges
+	_, order := ComputePolygonArea(ring)
+	if order == Invalid {
+		return nil
+	}
+	if order == CounterClockwise {
+		for i, j := 0, len(ring)-1; i < j; i, j = i+1, j-1 {
+			ring[i], ring[j] = ring[j], ring[i]
+		}
+	}
+
+	directions := make([]vector.Vector2, len(ring))
+	for i := range ring {
+		directions[i] = ring[i].To(ring[(i+1)%len(ring)]).Normalized()
+	}
+
+	var offset []vector.Vector2
+	add := func(p vector.Vector2) {
+		if len(offset) == 0 || offset[len(offset)-1] != p {
+			offset = append(offset, p)
+		}
+	}
+	for i, p := range ring {
+		if distance == 0 {
+			add(p)
+			continue
+		}
+
+		incoming := directions[(i-1+len(directions))%len(directions)]
+		outgoing := directions[i]
+		m1 := vector.Vector2{X: -incoming.Y, Y: incoming.X}.Mul(distance)
+		m2 := vector.Vector2{X: -outgoing.Y, Y: outgoing.X}.Mul(distance)
+		sin := incoming.Cross(outgoing)
+
+		switch {
+		case math.Abs(sin) < sinTolerance && incoming.Dot(outgoing) > 0:
+			// the edges are collinear
+			add(p.Add(m1))
+		case sin*distance > 0:
+			// the offset edges overlap around a concave vertex, and the loop they form is removed afterwards
+			add(p.Add(m1))
+			add(p)
+			add(p.Add(m2))
+		default:
+			joinOffsetEdges(p, incoming, outgoing, m1, m2, math.Abs(distance), options, add)
+		}
+	}
+	add(offset[0])
+	offset = offset[:len(offset)-1]
+
+	// the region is covered by the offset ring in clockwise order, where its winding number is negative
+	o := newOverlay([][][]vector.Vector2{{offset}})
+	return removeColinearRings(o.trace(func(winding []int) bool {
+		return winding[0] < 0
+	}))
+}
+
+// joinOffsetEdges adds the points joining two offset edges around the convex vertex p, where m1 and m2 are the
+// offsets of the incoming and outgoing edges and d their length
+func joinOffsetEdges(p, incoming, outgoing, m1, m2 vector.Vector2, d float64, options OffsetOptions, add func(vector.Vector2)) {
+	cos := m1.Dot(m2) / (d * d)
+
+	if options.Join == MiterJoin {
+		limit := options.MiterLimit
+		if limit == 0 {
+			limit = 2
+		}
+		// the miter lies at a distance of d / cos(θ/2) from the vertex, where θ is the angle between the normals
+		if 1+cos >= 2/(limit*limit) {
+			add(p.Add(m1.Add(m2).Div(1 + cos)))
+			return
+		}
+	}
+
+	// the join is centred around the bisector of the normals, or around the incoming edge if they are opposite
+	bisector := m1.Add(m2)
+	if bisector.Magnitude() < d*sinTolerance {
+		bisector = incoming
+	}
+	bisector = bisector.Normalized()
+
+	if options.Join == RoundJoin {
+		tolerance := options.ArcTolerance
+		if tolerance <= 0 {
+			tolerance = d / 100
+		}
+		angle := math.Acos(math.Max(-1, math.Min(1, cos)))
+		step := 2 * math.Acos(math.Max(0, 1-tolerance/d))
+		steps := int(math.Ceil(angle / step))
+		if m1.Cross(bisector) < 0 {
+			angle = -angle
+		}
+
+		add(p.Add(m1))
+		for i := 1; i < steps; i++ {
+			add(p.Add(rotate(m1, angle*float64(i)/float64(steps))))
+		}
+		add(p.Add(m2))
+		return
+	}
+
+	// the square join cuts the corner at a distance of d from the vertex, perpendicularly to the bisector
+	add(p.Add(m1).Add(incoming.Mul((d - m1.Dot(bisector)) / incoming.Dot(bisector))))
+	add(p.Add(m2).Add(outgoing.Mul((d - m2.Dot(bisector)) / outgoing.Dot(bisector))))
+}
+
+// rotate rotates the vector v counter-clockwise by an angle, in radians
+func rotate(v vector.Vector2, angle float64) vector.Vector2 {
+	sin, cos := math.Sincos(angle)
+	return vector.Vector2{X: v.X*cos - v.Y*sin, Y: v.X*sin + v.Y*cos}
+}
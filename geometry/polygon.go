@@ -0,0 +1,187 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// FillRule defines an enumerator representing the rule deciding which points are inside a polygon.
+type FillRule byte
+
+const (
+	EvenOdd FillRule = iota // a point is inside if a ray cast from it crosses the boundary an odd number of times
+	NonZero                 // a point is inside if the boundary winds around it at least once
+)
+
+// Polygon holds the vertices of a polygon along with the properties calculated from them,
+// so that they are only calculated once.
+type Polygon struct {
+	vertices  []vector.Vector2
+	min       vector.Vector2
+	max       vector.Vector2
+	area      float64
+	order     WindingOrder
+	perimeter float64
+	centroid  vector.Vector2
+	simple    bool
+	convex    bool
+	colinear  bool
+}
+
+// NewPolygon creates a polygon from a copy of its vertices.
+func NewPolygon(vertices []vector.Vector2) *Polygon {
+	p := &Polygon{vertices: append([]vector.Vector2{}, vertices...)}
+	p.area, p.order = ComputePolygonArea(p.vertices)
+	p.simple = IsSimplePolygon(p.vertices)
+	p.colinear = ContainsColinearEdges(p.vertices)
+
+	if len(p.vertices) == 0 {
+		return p
+	}
+	p.min, p.max = p.vertices[0], p.vertices[0]
+	for i, v := range p.vertices {
+		p.min = vector.Vector2{X: math.Min(p.min.X, v.X), Y: math.Min(p.min.Y, v.Y)}
+		p.max = vector.Vector2{X: math.Max(p.max.X, v.X), Y: math.Max(p.max.Y, v.Y)}
+		p.perimeter += v.Distance(p.vertices[(i+1)%len(p.vertices)])
+	}
+	p.centroid = computeCentroid(p.vertices)
+	p.convex = p.simple && p.order != Invalid && isConvex(p.vertices)
+	return p
+}
+
+// Len returns the number of vertices of the polygon.
+func (p *Polygon) Len() int {
+	return len(p.vertices)
+}
+
+// Vertex returns the vertex at index i.
+func (p *Polygon) Vertex(i int) vector.Vector2 {
+	return p.vertices[i]
+}
+
+// Vertices returns a copy of the vertices of the polygon.
+func (p *Polygon) Vertices() []vector.Vector2 {
+	return append([]vector.Vector2{}, p.vertices...)
+}
+
+// Edge returns the endpoints of the edge going from the vertex at index i to the next one.
+func (p *Polygon) Edge(i int) (a, b vector.Vector2) {
+	return p.vertices[i], p.vertices[(i+1)%len(p.vertices)]
+}
+
+// ForEachEdge calls the function for every edge of the polygon, in the order of its vertices.
+func (p *Polygon) ForEachEdge(f func(i int, a, b vector.Vector2)) {
+	for i := range p.vertices {
+		a, b := p.Edge(i)
+		f(i, a, b)
+	}
+}
+
+// Bounds returns the minimum and maximum corners of the axis-aligned box bounding the polygon.
+func (p *Polygon) Bounds() (min, max vector.Vector2) {
+	return p.min, p.max
+}
+
+// Area returns the area of the polygon.
+func (p *Polygon) Area() float64 {
+	return p.area
+}
+
+// WindingOrder returns the winding order of the vertices of the polygon.
+func (p *Polygon) WindingOrder() WindingOrder {
+	return p.order
+}
+
+// Perimeter returns the sum of the lengths of the edges of the polygon.
+func (p *Polygon) Perimeter() float64 {
+	return p.perimeter
+}
+
+// Centroid returns the centre of mass of the polygon,
+// or the average of its vertices if the polygon has no area.
+func (p *Polygon) Centroid() vector.Vector2 {
+	return p.centroid
+}
+
+// IsSimple determines whether the polygon is simple.
+func (p *Polygon) IsSimple() bool {
+	return p.simple
+}
+
+// IsConvex determines whether the polygon is simple and convex.
+func (p *Polygon) IsConvex() bool {
+	return p.convex
+}
+
+// ContainsColinearEdges determines if the polygon contains collinear edges.
+func (p *Polygon) ContainsColinearEdges() bool {
+	return p.colinear
+}
+
+// ContainsPoint determines whether the point is inside the polygon, according to the fill rule.
+// The points lying on the boundary are considered inside.
+func (p *Polygon) ContainsPoint(point vector.Vector2, rule FillRule) bool {
+	return ContainsPoint(p.vertices, point, rule)
+}
+
+// LocatePoint classifies the point as inside, outside or on the boundary of the polygon.
+func (p *Polygon) LocatePoint(point vector.Vector2, rule FillRule, tolerance float64) PointLocation {
+	if point.X < p.min.X-tolerance || point.X > p.max.X+tolerance || point.Y < p.min.Y-tolerance || point.Y > p.max.Y+tolerance {
+		return Outside
+	}
+	return LocatePoint(p.vertices, point, rule, tolerance)
+}
+
+// Reversed returns a copy of the polygon with its vertices in reverse order.
+func (p *Polygon) Reversed() *Polygon {
+	reversed := *p
+	reversed.vertices = p.Vertices()
+	vector.ReverseSlice(&reversed.vertices)
+	switch p.order {
+	case Clockwise:
+		reversed.order = CounterClockwise
+	case CounterClockwise:
+		reversed.order = Clockwise
+	}
+	return &reversed
+}
+
+// computeCentroid returns the centre of mass of a ring, or the average of its vertices if it has no area
+func computeCentroid(vertices []vector.Vector2) vector.Vector2 {
+	// the vertices are taken relative to the first one, to reduce the rounding errors
+	origin := vertices[0]
+	var centroid, average vector.Vector2
+	var area float64
+	for i := range vertices {
+		a := origin.To(vertices[i])
+		b := origin.To(vertices[(i+1)%len(vertices)])
+		cross := a.Cross(b)
+		area += cross
+		centroid = centroid.Add(a.Add(b).Mul(cross))
+		average = average.Add(a)
+	}
+
+	if area == 0 {
+		return origin.Add(average.Div(float64(len(vertices))))
+	}
+	return origin.Add(centroid.Div(3 * area))
+}
+
+// isConvex determines whether every vertex of a ring turns to the same side
+func isConvex(vertices []vector.Vector2) bool {
+	if len(vertices) < 3 {
+		return false
+	}
+
+	var left, right bool
+	for i := range vertices {
+		a := vertices[(i-1+len(vertices))%len(vertices)]
+		b := vertices[(i+1)%len(vertices)]
+		orientation := predicates.Orient2D(a, vertices[i], b)
+		left = left || orientation > 0
+		right = right || orientation < 0
+	}
+	return !(left && right)
+}
@@ -0,0 +1,112 @@
+package predicates
+
+import "math"
+
+// An expansion is an exact sum of float64 components, sorted by increasing magnitude and non-overlapping,
+// as described in "Adaptive Precision Floating-Point Arithmetic and Fast Robust Geometric Predicates".
+// Zero components are always eliminated, so the empty expansion represents zero.
+
+// twoSum returns the rounded sum of a and b, and its rounding error
+func twoSum(a, b float64) (x, y float64) {
+	x = a + b
+	bVirtual := x - a
+	aVirtual := x - bVirtual
+	y = (a - aVirtual) + (b - bVirtual)
+	return
+}
+
+// twoProduct returns the rounded product of a and b, and its rounding error
+func twoProduct(a, b float64) (x, y float64) {
+	x = a * b
+	y = math.FMA(a, b, -x)
+	return
+}
+
+// difference returns the expansion of a - b
+func difference(a, b float64) []float64 {
+	x, y := twoSum(a, -b)
+	return compress(y, x)
+}
+
+// compress returns the expansion of the given components, eliminating the zero ones
+func compress(components ...float64) []float64 {
+	e := components[:0]
+	for _, c := range components {
+		if c != 0 {
+			e = append(e, c)
+		}
+	}
+	return e
+}
+
+// grow returns the expansion of e + b
+func grow(e []float64, b float64) []float64 {
+	h := make([]float64, 0, len(e)+1)
+	q := b
+	for _, c := range e {
+		var r float64
+		q, r = twoSum(q, c)
+		if r != 0 {
+			h = append(h, r)
+		}
+	}
+	if q != 0 {
+		h = append(h, q)
+	}
+	return h
+}
+
+// add returns the expansion of e + f
+func add(e, f []float64) []float64 {
+	h := e
+	for _, c := range f {
+		h = grow(h, c)
+	}
+	return h
+}
+
+// subtract returns the expansion of e - f
+func subtract(e, f []float64) []float64 {
+	negated := make([]float64, len(f))
+	for i, c := range f {
+		negated[i] = -c
+	}
+	return add(e, negated)
+}
+
+// scale returns the expansion of e * b
+func scale(e []float64, b float64) []float64 {
+	var h []float64
+	for _, c := range e {
+		x, y := twoProduct(c, b)
+		h = add(h, compress(y, x))
+	}
+	return h
+}
+
+// multiply returns the expansion of e * f
+func multiply(e, f []float64) []float64 {
+	var h []float64
+	for _, c := range f {
+		h = add(h, scale(e, c))
+	}
+	return h
+}
+
+// estimate returns an approximation of the value of the expansion, with the same sign.
+// The components do not overlap, so the sign is the one of the largest component, while their rounded sum
+// may lose it when the largest components cancel out
+func estimate(e []float64) float64 {
+	if len(e) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, c := range e {
+		sum += c
+	}
+	if largest := e[len(e)-1]; sum == 0 || (sum > 0) != (largest > 0) {
+		return largest
+	}
+	return sum
+}
@@ -0,0 +1,108 @@
+// Package predicates provides robust geometric predicates, based on the adaptive precision floating-point
+// predicates of Jonathan Richard Shewchuk:
+// https://www.cs.cmu.edu/~quake/robust.html
+//
+// Each predicate is firstly evaluated with floating-point arithmetic, and its error bound is used to determine
+// whether the sign of the result can be trusted. Only when it can't, the result is calculated again with exact
+// expansion arithmetic, so the sign returned is always correct.
+package predicates
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// epsilon is the largest power of two such that 1 + epsilon rounds to 1
+const epsilon = 1.0 / (1 << 53)
+
+// error bounds of the floating-point evaluation of the predicates
+const (
+	orientErrorBound   = (3 + 16*epsilon) * epsilon
+	inCircleErrorBound = (10 + 96*epsilon) * epsilon
+)
+
+// Orient2D determines the orientation of the point c relative to the line from a to b.
+//
+// Returns a positive value if the points a, b and c are in counter-clockwise order (c lies to the left of
+// the line), a negative value if they are in clockwise order (c lies to the right of the line) and zero if
+// they are collinear. The result approximates twice the signed area of the triangle abc, but its sign is exact.
+func Orient2D(a, b, c vector.Vector2) float64 {
+	left := (a.X - c.X) * (b.Y - c.Y)
+	right := (a.Y - c.Y) * (b.X - c.X)
+	det := left - right
+
+	var sum float64
+	switch {
+	case left > 0 && right > 0:
+		sum = left + right
+	case left < 0 && right < 0:
+		sum = -left - right
+	default:
+		// the terms have opposite signs, so no cancellation may happen
+		return det
+	}
+
+	if math.Abs(det) >= orientErrorBound*sum {
+		return det
+	}
+	return orient2DExact(a, b, c)
+}
+
+// InCircle determines the position of the point d relative to the circle passing through the points a, b and c.
+//
+// Returns a positive value if d lies inside the circle, a negative value if it lies outside and zero if it lies
+// on it, given that a, b and c are in counter-clockwise order. The signs are reversed if they are in clockwise
+// order. The sign of the result is exact.
+func InCircle(a, b, c, d vector.Vector2) float64 {
+	adx, ady := a.X-d.X, a.Y-d.Y
+	bdx, bdy := b.X-d.X, b.Y-d.Y
+	cdx, cdy := c.X-d.X, c.Y-d.Y
+
+	bdxcdy, cdxbdy := bdx*cdy, cdx*bdy
+	cdxady, adxcdy := cdx*ady, adx*cdy
+	adxbdy, bdxady := adx*bdy, bdx*ady
+
+	aLift := adx*adx + ady*ady
+	bLift := bdx*bdx + bdy*bdy
+	cLift := cdx*cdx + cdy*cdy
+
+	det := aLift*(bdxcdy-cdxbdy) + bLift*(cdxady-adxcdy) + cLift*(adxbdy-bdxady)
+	permanent := (math.Abs(bdxcdy)+math.Abs(cdxbdy))*aLift +
+		(math.Abs(cdxady)+math.Abs(adxcdy))*bLift +
+		(math.Abs(adxbdy)+math.Abs(bdxady))*cLift
+
+	if math.Abs(det) > inCircleErrorBound*permanent {
+		return det
+	}
+	return inCircleExact(a, b, c, d)
+}
+
+// orient2DExact calculates the orientation of the points a, b and c with exact arithmetic
+func orient2DExact(a, b, c vector.Vector2) float64 {
+	acx := difference(a.X, c.X)
+	acy := difference(a.Y, c.Y)
+	bcx := difference(b.X, c.X)
+	bcy := difference(b.Y, c.Y)
+
+	det := subtract(multiply(acx, bcy), multiply(acy, bcx))
+	return estimate(det)
+}
+
+// inCircleExact calculates the position of the point d relative to the circle abc with exact arithmetic
+func inCircleExact(a, b, c, d vector.Vector2) float64 {
+	adx, ady := difference(a.X, d.X), difference(a.Y, d.Y)
+	bdx, bdy := difference(b.X, d.X), difference(b.Y, d.Y)
+	cdx, cdy := difference(c.X, d.X), difference(c.Y, d.Y)
+
+	aLift := add(multiply(adx, adx), multiply(ady, ady))
+	bLift := add(multiply(bdx, bdx), multiply(bdy, bdy))
+	cLift := add(multiply(cdx, cdx), multiply(cdy, cdy))
+
+	bc := subtract(multiply(bdx, cdy), multiply(cdx, bdy))
+	ca := subtract(multiply(cdx, ady), multiply(adx, cdy))
+	ab := subtract(multiply(adx, bdy), multiply(bdx, ady))
+
+	det := add(add(multiply(aLift, bc), multiply(bLift, ca)), multiply(cLift, ab))
+	return estimate(det)
+}
@@ -0,0 +1,148 @@
+package predicates
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// rat returns the exact value of the float
+func rat(x float64) *big.Rat {
+	return new(big.Rat).SetFloat64(x)
+}
+
+// exactOrient returns the sign of the orientation of the points a, b and c, with rational arithmetic
+func exactOrient(a, b, c vector.Vector2) int {
+	acx, acy := new(big.Rat).Sub(rat(a.X), rat(c.X)), new(big.Rat).Sub(rat(a.Y), rat(c.Y))
+	bcx, bcy := new(big.Rat).Sub(rat(b.X), rat(c.X)), new(big.Rat).Sub(rat(b.Y), rat(c.Y))
+	left := new(big.Rat).Mul(acx, bcy)
+	right := new(big.Rat).Mul(acy, bcx)
+	return left.Sub(left, right).Sign()
+}
+
+// exactInCircle returns the sign of the position of the point d relative to the circle abc, with rational arithmetic
+func exactInCircle(a, b, c, d vector.Vector2) int {
+	type delta struct{ x, y *big.Rat }
+	deltas := make([]delta, 3)
+	for i, p := range []vector.Vector2{a, b, c} {
+		deltas[i] = delta{new(big.Rat).Sub(rat(p.X), rat(d.X)), new(big.Rat).Sub(rat(p.Y), rat(d.Y))}
+	}
+
+	det := new(big.Rat)
+	for i, p := range deltas {
+		q, r := deltas[(i+1)%3], deltas[(i+2)%3]
+		lift := new(big.Rat).Add(new(big.Rat).Mul(p.x, p.x), new(big.Rat).Mul(p.y, p.y))
+		minor := new(big.Rat).Sub(new(big.Rat).Mul(q.x, r.y), new(big.Rat).Mul(r.x, q.y))
+		det.Add(det, lift.Mul(lift, minor))
+	}
+	return det.Sign()
+}
+
+// signOf returns the sign of the value, as -1, 0 or 1
+func signOf(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// nudge moves the value by the given number of representable floats
+func nudge(x float64, ulps int) float64 {
+	for ; ulps > 0; ulps-- {
+		x = math.Nextafter(x, math.Inf(1))
+	}
+	for ; ulps < 0; ulps++ {
+		x = math.Nextafter(x, math.Inf(-1))
+	}
+	return x
+}
+
+func TestOrient2DNearlyCollinear(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iteration := 0; iteration < 20000; iteration++ {
+		scale := math.Pow(10, float64(r.Intn(13)-6))
+		a := vector.Vector2{X: r.Float64() * scale, Y: r.Float64() * scale}
+		b := vector.Vector2{X: a.X + r.Float64()*1000*scale, Y: a.Y + r.Float64()*1000*scale}
+
+		// the point is rounded onto the line, and then moved by a few floats
+		s := r.Float64()*3 - 1
+		c := vector.Vector2{
+			X: nudge(a.X+(b.X-a.X)*s, r.Intn(5)-2),
+			Y: nudge(a.Y+(b.Y-a.Y)*s, r.Intn(5)-2),
+		}
+		if got, want := signOf(Orient2D(a, b, c)), exactOrient(a, b, c); got != want {
+			t.Fatalf("orientation of %v, %v and %v: got %d, want %d", a, b, c, got, want)
+		}
+	}
+}
+
+func TestOrient2DGrid(t *testing.T) {
+	// the points of a grid of consecutive floats around a line, as in the plots of Shewchuk's paper
+	b := vector.Vector2{X: 12, Y: 12}
+	c := vector.Vector2{X: 24, Y: 24}
+	for i := 0; i < 64; i++ {
+		for j := 0; j < 64; j++ {
+			a := vector.Vector2{X: nudge(0.5, i), Y: nudge(0.5, j)}
+			if got, want := signOf(Orient2D(a, b, c)), exactOrient(a, b, c); got != want {
+				t.Fatalf("orientation of %v, %v and %v: got %d, want %d", a, b, c, got, want)
+			}
+		}
+	}
+}
+
+func TestInCircleNearlyCocircular(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	onCircle := func(center vector.Vector2, radius float64) vector.Vector2 {
+		angle := r.Float64() * 2 * math.Pi
+		return vector.Vector2{X: center.X + radius*math.Cos(angle), Y: center.Y + radius*math.Sin(angle)}
+	}
+	for iteration := 0; iteration < 20000; iteration++ {
+		scale := math.Pow(10, float64(r.Intn(9)-4))
+		center := vector.Vector2{X: (r.Float64()*200 - 100) * scale, Y: (r.Float64()*200 - 100) * scale}
+		radius := (1 + r.Float64()*100) * scale
+
+		// the points are rounded onto the circle, and the last one is moved by a few floats
+		a, b, c, d := onCircle(center, radius), onCircle(center, radius), onCircle(center, radius), onCircle(center, radius)
+		d = vector.Vector2{X: nudge(d.X, r.Intn(5)-2), Y: nudge(d.Y, r.Intn(5)-2)}
+		if got, want := signOf(InCircle(a, b, c, d)), exactInCircle(a, b, c, d); got != want {
+			t.Fatalf("position of %v relative to %v, %v and %v: got %d, want %d", d, a, b, c, got, want)
+		}
+	}
+}
+
+func TestInCircleExactlyCocircular(t *testing.T) {
+	// the points of a circle with integer coordinates, and the points next to them
+	points := []vector.Vector2{{X: 5, Y: 0}, {X: 3, Y: 4}, {X: 0, Y: 5}, {X: -4, Y: 3}, {X: -5, Y: 0}, {X: 4, Y: -3}}
+	for _, d := range points[3:] {
+		for _, ulps := range []int{-1, 0, 1} {
+			d := vector.Vector2{X: nudge(d.X, ulps), Y: d.Y}
+			got := signOf(InCircle(points[0], points[1], points[2], d))
+			if want := exactInCircle(points[0], points[1], points[2], d); got != want {
+				t.Fatalf("position of %v: got %d, want %d", d, got, want)
+			}
+		}
+	}
+}
+
+func TestEstimateSign(t *testing.T) {
+	// the rounded sum of the components cancels out, while the largest component is positive
+	e := []float64{-(math.Ldexp(1, -54) + math.Ldexp(1, -60)), -(1 - math.Ldexp(1, -53)), 1}
+
+	sum := new(big.Rat)
+	for _, c := range e {
+		sum.Add(sum, rat(c))
+	}
+	if got, want := signOf(estimate(e)), sum.Sign(); got != want {
+		t.Fatalf("sign of %v: got %d, want %d", e, got, want)
+	}
+	if estimate(nil) != 0 {
+		t.Fatalf("the empty expansion should be zero")
+	}
+}
@@ -0,0 +1,232 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// PolygonWithHoles holds the outer ring of a polygon, in clockwise order, and its holes, in counter-clockwise order.
+type PolygonWithHoles struct {
+	Outer []vector.Vector2
+	Holes [][]vector.Vector2
+}
+
+// RepairPolygon splits a complex polygon into simple polygons with holes.
+//
+// Receives the vertices of the polygon, which may intersect themselves or repeat, and the fill rule deciding which
+// of the regions they enclose are inside.
+// The edges are split where they intersect, using the sweep-line algorithm, and the boundaries of the regions are
+// traced again. Collinear vertices are removed, and rings touching each other at a vertex are returned separately.
+// The polygons with a hole touching another of their rings are split along a horizontal line crossing that hole.
+// Returns the resulting polygons, which meet the requirements of earclipping.TriangulateWithHoles.
+func RepairPolygon(vertices []vector.Vector2, rule FillRule) []PolygonWithHoles {
+	o := newOverlay([][][]vector.Vector2{{vertices}})
+	rings := o.trace(func(winding []int) bool {
+		if rule == NonZero {
+			return winding[0] != 0
+		}
+		return winding[0]%2 != 0
+	})
+	return separateTouchingHoles(groupRings(splitRings(rings)))
+}
+
+// splitRings splits the traced rings at their repeated vertices, so that the holes touching their outer ring are
+// returned separately
+func splitRings(rings [][]vector.Vector2) (result [][]vector.Vector2) {
+	for _, ring := range rings {
+		for _, loop := range splitLoops(ring) {
+			if loop = RemoveColinearVertices(loop, 0); len(loop) >= 3 {
+				result = append(result, loop)
+			}
+		}
+	}
+	return
+}
+
+// splitLoops splits a ring at its repeated vertices, which join it to holes touching it, into simple loops
+func splitLoops(ring []vector.Vector2) (loops [][]vector.Vector2) {
+	var stack []vector.Vector2
+	positions := make(map[vector.Vector2]int, len(ring))
+	for _, v := range ring {
+		if i, ok := positions[v]; ok {
+			// the vertices since the previous visit form a loop
+			loops = append(loops, append([]vector.Vector2{}, stack[i:]...))
+			for _, w := range stack[i+1:] {
+				delete(positions, w)
+			}
+			stack = stack[:i+1]
+			continue
+		}
+		positions[v] = len(stack)
+		stack = append(stack, v)
+	}
+	return append(loops, stack)
+}
+
+// groupRings assigns every counter-clockwise hole to the smallest clockwise outer ring containing it, or to the
+// closest one if none contains it. The rings must not cross each other.
+func groupRings(rings [][]vector.Vector2) []PolygonWithHoles {
+	var polygons []PolygonWithHoles
+	var areas []float64
+	var holes [][]vector.Vector2
+	for _, ring := range rings {
+		area, order := ComputePolygonArea(ring)
+		if order == Clockwise {
+			polygons = append(polygons, PolygonWithHoles{Outer: ring})
+			areas = append(areas, area)
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+
+	for _, hole := range holes {
+		best := -1
+		for i, polygon := range polygons {
+			if (best < 0 || areas[i] < areas[best]) && isRingInside(hole, polygon.Outer) {
+				best = i
+			}
+		}
+		if best < 0 {
+			// a hole must lie inside some outer ring, so the rounding of its vertices is blamed and the closest
+			// outer ring takes it
+			best = closestRing(hole, polygons)
+		}
+		if best >= 0 {
+			polygons[best].Holes = append(polygons[best].Holes, hole)
+		}
+	}
+	return polygons
+}
+
+// closestRing returns the index of the polygon whose outer ring is the closest to the vertices of the ring,
+// or -1 if there are no polygons
+func closestRing(ring []vector.Vector2, polygons []PolygonWithHoles) int {
+	best := -1
+	bestDistance := math.Inf(1)
+	for i, polygon := range polygons {
+		for j, a := range polygon.Outer {
+			b := polygon.Outer[(j+1)%len(polygon.Outer)]
+			for _, v := range ring {
+				if distance := distanceToSegment(v, a, b); distance < bestDistance {
+					best = i
+					bestDistance = distance
+				}
+			}
+		}
+	}
+	return best
+}
+
+// isRingInside determines whether a ring lies inside another ring which it does not cross,
+// deciding by its first vertex which is not on the boundary of the other ring
+func isRingInside(ring, other []vector.Vector2) bool {
+	for _, v := range ring {
+		switch LocatePoint(other, v, EvenOdd, 0) {
+		case Inside:
+			return true
+		case Outside:
+			return false
+		}
+	}
+
+	// every vertex lies on the other ring, so the midpoints of the edges decide
+	for i, v := range ring {
+		m := vector.Lerp(v, ring[(i+1)%len(ring)], 0.5)
+		switch LocatePoint(other, m, EvenOdd, 0) {
+		case Inside:
+			return true
+		case Outside:
+			return false
+		}
+	}
+	return false
+}
+
+// separateTouchingHoles splits the polygons having a hole which touches another of their rings, along a horizontal
+// line crossing that hole, until no hole touches another ring
+func separateTouchingHoles(pending []PolygonWithHoles) (polygons []PolygonWithHoles) {
+	for len(pending) != 0 {
+		polygon := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		hole := findTouchingHole(polygon)
+		if hole == nil {
+			polygons = append(polygons, polygon)
+			continue
+		}
+
+		// the line opens the hole, and the pieces touching each other at a vertex become separate polygons.
+		// Both pieces are clipped by the same half-plane, so that they share the points where the line meets the rings
+		y := cutHeight(polygon, hole)
+		min := vector.Vector2{X: math.Inf(1), Y: math.Inf(1)}
+		max := vector.Vector2{X: math.Inf(-1), Y: math.Inf(-1)}
+		for _, v := range polygon.Outer {
+			min = vector.Vector2{X: math.Min(min.X, v.X), Y: math.Min(min.Y, v.Y)}
+			max = vector.Vector2{X: math.Max(max.X, v.X), Y: math.Max(max.Y, v.Y)}
+		}
+		rings := append([][]vector.Vector2{polygon.Outer}, polygon.Holes...)
+		below := [][]vector.Vector2{{
+			{X: min.X - 1, Y: min.Y - 1}, {X: min.X - 1, Y: y}, {X: max.X + 1, Y: y}, {X: max.X + 1, Y: min.Y - 1},
+		}}
+		for _, operation := range []BooleanOperation{Intersection, Difference} {
+			o := newOverlay([][][]vector.Vector2{rings, below})
+			pending = append(pending, groupRings(splitRings(o.trace(operation.inside)))...)
+		}
+	}
+	return
+}
+
+// findTouchingHole returns a hole of the polygon touching another of its rings, or nil if there is none
+func findTouchingHole(polygon PolygonWithHoles) []vector.Vector2 {
+	rings := append([][]vector.Vector2{polygon.Outer}, polygon.Holes...)
+	locators := make([]*PointLocator, len(rings))
+	for i, ring := range rings {
+		locators[i] = NewPointLocator(ring, EvenOdd, 0)
+	}
+
+	for h, hole := range polygon.Holes {
+		for i, locator := range locators {
+			if i == h+1 {
+				continue
+			}
+			for _, v := range hole {
+				if location := locator.LocatePoint(v); location == OnEdge || location == OnVertex {
+					return hole
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// cutHeight returns the height of a horizontal line crossing the interior of the hole without passing through any
+// vertex of the polygon, as far as possible from them
+func cutHeight(polygon PolygonWithHoles, hole []vector.Vector2) float64 {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range hole {
+		low = math.Min(low, v.Y)
+		high = math.Max(high, v.Y)
+	}
+
+	var heights []float64
+	for _, ring := range append([][]vector.Vector2{polygon.Outer}, polygon.Holes...) {
+		for _, v := range ring {
+			if v.Y >= low && v.Y <= high {
+				heights = append(heights, v.Y)
+			}
+		}
+	}
+	sort.Float64s(heights)
+
+	y := (low + high) / 2
+	gap := 0.0
+	for i := 1; i < len(heights); i++ {
+		if heights[i]-heights[i-1] > gap {
+			gap = heights[i] - heights[i-1]
+			y = (heights[i] + heights[i-1]) / 2
+		}
+	}
+	return y
+}
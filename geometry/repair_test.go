@@ -0,0 +1,104 @@
+package geometry
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// repairedArea returns the area covered by the polygons, failing if any of them has a hole touching another ring
+func repairedArea(t *testing.T, polygons []PolygonWithHoles) float64 {
+	t.Helper()
+	var total float64
+	for _, polygon := range polygons {
+		if findTouchingHole(polygon) != nil {
+			t.Fatalf("a hole of %v touches another ring", polygon)
+		}
+		area, order := ComputePolygonArea(polygon.Outer)
+		if order != Clockwise {
+			t.Fatalf("the outer ring of %v is not clockwise", polygon)
+		}
+		total += area
+		for _, hole := range polygon.Holes {
+			area, order := ComputePolygonArea(hole)
+			if order != CounterClockwise {
+				t.Fatalf("the hole %v is not counter-clockwise", hole)
+			}
+			total -= area
+		}
+	}
+	return total
+}
+
+// clippedArea returns the area covered by the rings, where the holes are counter-clockwise
+func clippedArea(rings [][]vector.Vector2) float64 {
+	var total float64
+	for _, ring := range rings {
+		area, order := ComputePolygonArea(ring)
+		if order == Clockwise {
+			total += area
+		} else {
+			total -= area
+		}
+	}
+	return total
+}
+
+// isRepairedInside determines whether the point lies inside one of the polygons, and outside its holes
+func isRepairedInside(polygons []PolygonWithHoles, p vector.Vector2) bool {
+	for _, polygon := range polygons {
+		if LocatePoint(polygon.Outer, p, EvenOdd, 0) != Inside {
+			continue
+		}
+		inHole := false
+		for _, hole := range polygon.Holes {
+			if LocatePoint(hole, p, EvenOdd, 0) != Outside {
+				inHole = true
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRepairPolygonKeepsCutPieces(t *testing.T) {
+	// the hole touches the outer ring, and cutting it used to drop the pieces on the right of the cut
+	vertices := []vector.Vector2{
+		{X: 4, Y: 3}, {X: 1, Y: 1}, {X: 2, Y: 4}, {X: 0, Y: 4}, {X: 1, Y: 2},
+		{X: 0, Y: 4}, {X: 5, Y: 5}, {X: 3, Y: 0}, {X: 2, Y: 3},
+	}
+	polygons := RepairPolygon(vertices, EvenOdd)
+
+	want := clippedArea(ClipPolygons([][]vector.Vector2{vertices}, nil, Union))
+	if got := repairedArea(t, polygons); math.Abs(got-want) > 1e-9 {
+		t.Fatalf("got an area of %v, want %v, in %v", got, want, polygons)
+	}
+	for _, p := range []vector.Vector2{{X: 3.5, Y: 2}, {X: 3.2, Y: 3.5}, {X: 4, Y: 4.2}} {
+		if !isRepairedInside(polygons, p) {
+			t.Fatalf("%v should lie inside %v", p, polygons)
+		}
+	}
+	if p := (vector.Vector2{X: 3, Y: 2.8}); isRepairedInside(polygons, p) {
+		t.Fatalf("%v lies in the hole, but is inside %v", p, polygons)
+	}
+}
+
+func TestRepairPolygonRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iteration := 0; iteration < 20000; iteration++ {
+		vertices := make([]vector.Vector2, 3+r.Intn(10))
+		for i := range vertices {
+			vertices[i] = vector.Vector2{X: float64(r.Intn(6)), Y: float64(r.Intn(6))}
+		}
+
+		// the clipping with no clip rings keeps the regions inside under the even-odd rule
+		want := clippedArea(ClipPolygons([][]vector.Vector2{vertices}, nil, Union))
+		if got := repairedArea(t, RepairPolygon(vertices, EvenOdd)); math.Abs(got-want) > 1e-9 {
+			t.Fatalf("got an area of %v, want %v, for %v", got, want, vertices)
+		}
+	}
+}
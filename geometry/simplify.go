@@ -0,0 +1,328 @@
+package geometry
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	"github.com/mindera-gaming/go-math/geometry/sweepline"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// SimplifyOptions is the structure that defines the simplification options.
+type SimplifyOptions struct {
+	Closed           bool // set to true to treat the points as a closed ring instead of a polyline
+	PreserveTopology bool // set to true to keep the vertices needed to avoid new self-intersections
+}
+
+// SimplifyDouglasPeucker removes the vertices of a polyline or ring which deviate little from its shape,
+// using the Ramer–Douglas–Peucker algorithm.
+//
+// Receives the points, the distance tolerance and the simplification options.
+// Every removed vertex lies closer than the tolerance to the simplified edge replacing it.
+// The endpoints of a polyline are always kept, and a ring keeps at least 3 vertices.
+// Returns the remaining points, in their original order.
+func SimplifyDouglasPeucker(points []vector.Vector2, tolerance float64, options SimplifyOptions) []vector.Vector2 {
+	if len(points) <= 2 || (options.Closed && len(points) <= 3) {
+		return append([]vector.Vector2{}, points...)
+	}
+
+	keep := make([]bool, len(points))
+	if !options.Closed {
+		keep[0] = true
+		keep[len(points)-1] = true
+		douglasPeucker(points, keep, 0, len(points)-1, tolerance)
+	} else {
+		// a ring is split at the vertex farthest from the first one, and both of its halves are kept apart
+		farthest := 0
+		for i, p := range points {
+			if p.DistanceSqr(points[0]) > points[farthest].DistanceSqr(points[0]) {
+				farthest = i
+			}
+		}
+		if farthest == 0 {
+			return append([]vector.Vector2{}, points...)
+		}
+		keep[0] = true
+		keep[farthest] = true
+		douglasPeucker(points, keep, 0, farthest, tolerance)
+		douglasPeucker(points, keep, farthest, len(points), tolerance)
+
+		// the third vertex is the one farthest from the other two
+		if count(keep) < 3 {
+			third := 0
+			for i, p := range points {
+				if distanceToSegment(p, points[0], points[farthest]) > distanceToSegment(points[third], points[0], points[farthest]) {
+					third = i
+				}
+			}
+			keep[third] = true
+		}
+	}
+
+	if options.PreserveTopology {
+		restoreTopology(points, keep, options.Closed)
+	}
+	return keptPoints(points, keep)
+}
+
+// douglasPeucker keeps the vertices between the indices from and to, exclusive, which lie farther than the tolerance
+// from the segment joining them. The index to may be the length of a ring, referring to its first vertex.
+func douglasPeucker(points []vector.Vector2, keep []bool, from, to int, tolerance float64) {
+	if to-from < 2 {
+		return
+	}
+
+	farthest := farthestBetween(points, from, to)
+	if distanceToSegment(points[farthest], points[from], points[to%len(points)]) <= tolerance {
+		return
+	}
+	keep[farthest] = true
+	douglasPeucker(points, keep, from, farthest, tolerance)
+	douglasPeucker(points, keep, farthest, to, tolerance)
+}
+
+// farthestBetween returns the index of the point between the indices from and to, exclusive, which lies farthest
+// from the segment joining them. The index to may exceed the length of a ring, wrapping around it.
+func farthestBetween(points []vector.Vector2, from, to int) int {
+	a := points[from%len(points)]
+	b := points[to%len(points)]
+	farthest := -1
+	farthestDistance := -1.0
+	for i := from + 1; i < to; i++ {
+		if distance := distanceToSegment(points[i%len(points)], a, b); distance > farthestDistance {
+			farthest = i % len(points)
+			farthestDistance = distance
+		}
+	}
+	return farthest
+}
+
+// SimplifyVisvalingam removes the vertices of a polyline or ring which add little area to its shape,
+// using the Visvalingam–Whyatt algorithm.
+//
+// Receives the points, the area tolerance and the simplification options.
+// The vertex forming the triangle with the smallest area along with its neighbours is removed repeatedly,
+// as long as that area is below the tolerance.
+// The endpoints of a polyline are always kept, and a ring keeps at least 3 vertices.
+// Returns the remaining points, in their original order.
+func SimplifyVisvalingam(points []vector.Vector2, tolerance float64, options SimplifyOptions) []vector.Vector2 {
+	n := len(points)
+	if n <= 2 || (options.Closed && n <= 3) {
+		return append([]vector.Vector2{}, points...)
+	}
+
+	keep := make([]bool, n)
+	previous := make([]int, n)
+	next := make([]int, n)
+	for i := range points {
+		keep[i] = true
+		previous[i] = (i - 1 + n) % n
+		next[i] = (i + 1) % n
+	}
+
+	area := func(i int) float64 {
+		return math.Abs(points[previous[i]].To(points[i]).Cross(points[previous[i]].To(points[next[i]]))) / 2
+	}
+	removable := func(i int) bool {
+		return options.Closed || (i != 0 && i != n-1)
+	}
+
+	queue := make(vertexQueue, 0, n)
+	entries := make([]*vertexEntry, n)
+	for i := range points {
+		if removable(i) {
+			entries[i] = &vertexEntry{vertex: i, area: area(i)}
+			heap.Push(&queue, entries[i])
+		}
+	}
+
+	remaining := n
+	minimum := 2
+	if options.Closed {
+		minimum = 3
+	}
+	for queue.Len() != 0 && remaining > minimum {
+		entry := heap.Pop(&queue).(*vertexEntry)
+		if entry.area >= tolerance {
+			break
+		}
+
+		i := entry.vertex
+		keep[i] = false
+		entries[i] = nil
+		remaining--
+		next[previous[i]] = next[i]
+		previous[next[i]] = previous[i]
+
+		// the areas of the neighbours never decrease below the area just removed
+		for _, neighbour := range []int{previous[i], next[i]} {
+			if entries[neighbour] != nil {
+				entries[neighbour].area = math.Max(area(neighbour), entry.area)
+				heap.Fix(&queue, entries[neighbour].index)
+			}
+		}
+	}
+
+	if options.PreserveTopology {
+		restoreTopology(points, keep, options.Closed)
+	}
+	return keptPoints(points, keep)
+}
+
+// vertexEntry is a vertex of the Visvalingam–Whyatt queue
+type vertexEntry struct {
+	vertex int
+	area   float64
+	index  int
+}
+
+// vertexQueue is a priority queue of vertices, ordered by their effective area
+type vertexQueue []*vertexEntry
+
+func (q vertexQueue) Len() int {
+	return len(q)
+}
+
+func (q vertexQueue) Less(i, j int) bool {
+	return q[i].area < q[j].area || (q[i].area == q[j].area && q[i].vertex < q[j].vertex)
+}
+
+func (q vertexQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *vertexQueue) Push(x interface{}) {
+	entry := x.(*vertexEntry)
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *vertexQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
+
+// restoreTopology keeps again the removed vertices needed so that the simplified edges do not intersect each other,
+// using the sweep-line algorithm. The intersections between original edges are left as they are.
+func restoreTopology(points []vector.Vector2, keep []bool, closed bool) {
+	for {
+		var kept []int
+		for i, k := range keep {
+			if k {
+				kept = append(kept, i)
+			}
+		}
+
+		edges := len(kept) - 1
+		if closed {
+			edges = len(kept)
+		}
+		segments := make([]sweepline.Segment, edges)
+		for s := range segments {
+			segments[s] = sweepline.NewSegment(points[kept[s]], points[kept[(s+1)%len(kept)]])
+		}
+
+		// every simplified edge involved in an intersection gets back the vertex farthest from it
+		restored := false
+		intersections := sweepline.FindSegmentIntersections(segments, sweepline.Options{
+			Endpoints: sweepline.IgnoreSharedEndpoints,
+		})
+		for _, intersection := range intersections {
+			for _, s := range intersection.Segments {
+				from, to := kept[s], kept[(s+1)%len(kept)]
+				if to <= from {
+					to += len(points)
+				}
+				if to-from >= 2 {
+					if farthest := farthestBetween(points, from, to); !keep[farthest] {
+						keep[farthest] = true
+						restored = true
+					}
+				}
+			}
+		}
+		if !restored {
+			return
+		}
+	}
+}
+
+// keptPoints returns the points which are kept
+func keptPoints(points []vector.Vector2, keep []bool) []vector.Vector2 {
+	result := make([]vector.Vector2, 0, count(keep))
+	for i, p := range points {
+		if keep[i] {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// count returns the number of true values
+func count(values []bool) (n int) {
+	for _, v := range values {
+		if v {
+			n++
+		}
+	}
+	return
+}
+
+// RemoveColinearVertices removes the vertices of a ring lying on the line of their neighbours,
+// so that the ring no longer contains collinear edges.
+//
+// Receives the vertices of the ring and the distance tolerance. A vertex is removed if it lies closer than the
+// tolerance to the line of its neighbours, or exactly on it when the tolerance is zero. Repeated vertices are
+// removed as well.
+// Returns the remaining vertices, in their original order.
+func RemoveColinearVertices(vertices []vector.Vector2, tolerance float64) []vector.Vector2 {
+	n := len(vertices)
+	previous := make([]int, n)
+	next := make([]int, n)
+	removed := make([]bool, n)
+	for i := range vertices {
+		previous[i] = (i - 1 + n) % n
+		next[i] = (i + 1) % n
+	}
+
+	isColinear := func(i int) bool {
+		a, b, c := vertices[previous[i]], vertices[i], vertices[next[i]]
+		if a == b || b == c || predicates.Orient2D(a, b, c) == 0 {
+			return true
+		}
+		return tolerance > 0 && a.To(c).Magnitude() > 0 && math.Abs(a.To(b).Cross(a.To(c)))/a.To(c).Magnitude() <= tolerance
+	}
+
+	// the neighbours of every removed vertex are checked again
+	pending := make([]int, n)
+	for i := range pending {
+		pending[i] = n - 1 - i
+	}
+	remaining := n
+	for len(pending) != 0 && remaining >= 3 {
+		i := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if removed[i] || !isColinear(i) {
+			continue
+		}
+
+		removed[i] = true
+		remaining--
+		next[previous[i]] = next[i]
+		previous[next[i]] = previous[i]
+		pending = append(pending, next[i], previous[i])
+	}
+
+	result := make([]vector.Vector2, 0, remaining)
+	for i, v := range vertices {
+		if !removed[i] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
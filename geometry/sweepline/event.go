@@ -14,31 +14,57 @@ const (
 // event defines an event point
 type event struct {
 	Point    vector.Vector2
-	Segments []Segment
-	Value    float64
-	Type     eventType
+	Segments []Segment // segments given to NewEvent, NewEventSingleSegment and AddSegment
+	Type     eventType // type given to NewEvent and NewEventSingleSegment
+	Upper    []int     // indices of the segments starting at the event point
+	Crossing []int     // indices of the segments known to pass through the event point
+	handled  bool      // whether the event was already removed from the queue
 }
 
-// NewEventSingleSegment returns a new event with a single segment
+// NewEventSingleSegment returns a new event with a single segment.
+//
+// Deprecated: FindSegmentIntersections neither reads nor fills the segments and the type of its events.
 func NewEventSingleSegment(p vector.Vector2, s Segment, t eventType) (e event) {
 	e.Point = p
 	e.Segments = append(e.Segments, s)
-	e.Value = p.X
 	e.Type = t
 	return
 }
 
-// NewEvent returns a new event with a set of segments
+// NewEvent returns a new event with a set of segments.
+//
+// Deprecated: FindSegmentIntersections neither reads nor fills the segments and the type of its events.
 func NewEvent(p vector.Vector2, s []Segment, t eventType) event {
 	return event{
 		Point:    p,
 		Segments: s,
-		Value:    p.X,
 		Type:     t,
 	}
 }
 
-// AddSegment adds a new segment to the event
+// AddSegment adds a new segment to the event.
+//
+// Deprecated: FindSegmentIntersections neither reads nor fills the segments and the type of its events.
 func (e *event) AddSegment(s Segment) {
 	e.Segments = append(e.Segments, s)
 }
+
+// addCrossing adds a segment passing through the event point, if it was not added yet
+func (e *event) addCrossing(segment int) {
+	for _, s := range e.Crossing {
+		if s == segment {
+			return
+		}
+	}
+	e.Crossing = append(e.Crossing, segment)
+}
+
+// isCrossing determines whether the segment is known to pass through the event point
+func (e *event) isCrossing(segment int) bool {
+	for _, s := range e.Crossing {
+		if s == segment {
+			return true
+		}
+	}
+	return false
+}
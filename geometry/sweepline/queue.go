@@ -0,0 +1,82 @@
+package sweepline
+
+import (
+	"container/heap"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// QueuedEvent defines an event point held by an event queue, along with the data of the algorithm handling it
+type QueuedEvent struct {
+	Point vector.Vector2
+	Data  interface{}
+	index int // position of the event in the queue, or -1 once it has left it
+}
+
+// EventQueue defines a priority queue of event points, sweeping them from left to right and, along the sweep-line,
+// from bottom to top. The zero value is an empty queue
+type EventQueue struct {
+	events queuedEvents
+}
+
+// Len returns the number of queued events
+func (q *EventQueue) Len() int {
+	return len(q.events)
+}
+
+// Push adds an event point to the queue, returning the queued event
+func (q *EventQueue) Push(p vector.Vector2, data interface{}) *QueuedEvent {
+	e := &QueuedEvent{Point: p, Data: data}
+	heap.Push(&q.events, e)
+	return e
+}
+
+// Peek returns the first event of the queue, without removing it
+func (q *EventQueue) Peek() *QueuedEvent {
+	return q.events[0]
+}
+
+// Pop removes and returns the first event of the queue
+func (q *EventQueue) Pop() *QueuedEvent {
+	return heap.Pop(&q.events).(*QueuedEvent)
+}
+
+// Remove removes the event from the queue, unless it has already left it
+func (q *EventQueue) Remove(e *QueuedEvent) {
+	if e.index >= 0 && e.index < len(q.events) && q.events[e.index] == e {
+		heap.Remove(&q.events, e.index)
+	}
+}
+
+// precedes determines whether the point p comes before the point q, sweeping from left to right
+// and, along the sweep-line, from bottom to top
+func precedes(p, q vector.Vector2) bool {
+	return p.X < q.X || (p.X == q.X && p.Y < q.Y)
+}
+
+// queuedEvents implements heap.Interface for the event queue
+type queuedEvents []*QueuedEvent
+
+func (q queuedEvents) Len() int { return len(q) }
+
+func (q queuedEvents) Less(i, j int) bool { return precedes(q[i].Point, q[j].Point) }
+
+func (q queuedEvents) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *queuedEvents) Push(x interface{}) {
+	e := x.(*QueuedEvent)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *queuedEvents) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	e.index = -1
+	*q = old[:len(old)-1]
+	return e
+}
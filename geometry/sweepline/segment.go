@@ -1,6 +1,9 @@
 package sweepline
 
 import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
 	vector "github.com/mindera-gaming/go-math/vector2"
 )
 
@@ -21,28 +24,33 @@ func NewSegment(pointA, pointB vector.Vector2) Segment {
 	return s
 }
 
-// First returns the first point of the segment
+// First returns the first point of the segment, from left to right and then from bottom to top
 func (s Segment) First() vector.Vector2 {
-	if s.A.X <= s.B.X {
+	if s.A.X < s.B.X || (s.A.X == s.B.X && s.A.Y <= s.B.Y) {
 		return s.A
 	} else {
 		return s.B
 	}
 }
 
-// Second returns the Second point of the segment
+// Second returns the Second point of the segment, from left to right and then from bottom to top
 func (s Segment) Second() vector.Vector2 {
-	if s.A.X <= s.B.X {
+	if s.A.X < s.B.X || (s.A.X == s.B.X && s.A.Y <= s.B.Y) {
 		return s.B
 	} else {
 		return s.A
 	}
 }
 
-// CalculateValue calculates the segment value
+// CalculateValue calculates the segment value, the Y coordinate of the segment at the given X coordinate.
+// The value of a vertical segment is the Y coordinate of its first point.
 func (s *Segment) CalculateValue(value float64) {
 	first := s.First()
 	second := s.Second()
+	if first.X == second.X {
+		s.Value = first.Y
+		return
+	}
 	s.Value = first.Y + (((second.Y - first.Y) / (second.X - first.X)) * (value - first.X))
 }
 
@@ -59,3 +67,54 @@ func (s Segment) Equals(segment Segment) bool {
 	}
 	return true
 }
+
+// direction returns the vector from the first point to the second point of the segment
+func (s Segment) direction() vector.Vector2 {
+	return s.First().To(s.Second())
+}
+
+// orientation returns a positive value if the point p lies above the segment, a negative value if it lies below
+// and zero if it lies on its line
+func (s Segment) orientation(p vector.Vector2) float64 {
+	return predicates.Orient2D(s.First(), s.Second(), p)
+}
+
+// isParallel determines whether two segments are parallel, within the rounding errors of their directions
+func (s Segment) isParallel(other Segment) bool {
+	r := s.direction()
+	q := other.direction()
+	return math.Abs(r.Cross(q)) <= relativeTolerance*r.Magnitude()*q.Magnitude()
+}
+
+// distance returns the distance from the point p to the line of the segment
+func (s Segment) distance(p vector.Vector2) float64 {
+	return math.Abs(s.orientation(p)) / s.direction().Magnitude()
+}
+
+// intersect returns the point where two non-parallel segments intersect, if they do.
+// An intersection point closer than the tolerance to an endpoint is replaced by the endpoint.
+func (s Segment) intersect(other Segment, tolerance float64) (p vector.Vector2, ok bool) {
+	if s.isParallel(other) {
+		return
+	}
+
+	r := s.direction()
+	q := other.direction()
+	denominator := r.Cross(q)
+
+	w := s.First().To(other.First())
+	t := w.Cross(q) / denominator
+	u := w.Cross(r) / denominator
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return
+	}
+
+	// the endpoints are returned exactly, so that their events are shared
+	p = s.First().Add(r.Mul(t))
+	for _, endpoint := range []vector.Vector2{s.A, s.B, other.A, other.B} {
+		if p.Distance(endpoint) <= tolerance {
+			return endpoint, true
+		}
+	}
+	return p, true
+}
@@ -0,0 +1,150 @@
+package sweepline
+
+// Status holds the indices of the items crossing a sweep-line, such as segments or edges, in the order in which
+// they cross it. The items are kept in a treap, so that they are found, inserted and removed in logarithmic
+// expected time, as long as the order given by the callers stays consistent. The zero value is an empty status
+type Status struct {
+	root *statusNode
+	seed uint32 // state of the generator of the node priorities
+}
+
+// statusNode defines a node of the status treap, holding an item
+type statusNode struct {
+	item        int
+	priority    uint32 // the priority of a node is never lower than the ones of its children
+	left, right *statusNode
+}
+
+// Insert adds the item to the status, after every item for which before returns true, which must come first
+func (t *Status) Insert(item int, before func(other int) bool) {
+	left, right := split(t.root, before)
+	t.root = merge(merge(left, t.newNode(item)), right)
+}
+
+// Remove deletes the item from the status, looking for it first after the items for which before returns true,
+// which must come first, and then among them, in case the order was misjudged.
+// Returns false if the item is not in the status
+func (t *Status) Remove(item int, before func(other int) bool) bool {
+	left, right := split(t.root, before)
+	right, ok := removeItem(right, item)
+	if !ok {
+		left, ok = removeItem(left, item)
+	}
+	t.root = merge(left, right)
+	return ok
+}
+
+// Last returns the last item for which before returns true, which must come first.
+// Returns false if there is none
+func (t *Status) Last(before func(other int) bool) (item int, ok bool) {
+	for n := t.root; n != nil; {
+		if before(n.item) {
+			item, ok = n.item, true
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return
+}
+
+// newNode creates a node holding the item, with a pseudo-random priority from a xorshift generator
+func (t *Status) newNode(item int) *statusNode {
+	if t.seed == 0 {
+		t.seed = 2463534242
+	}
+	t.seed ^= t.seed << 13
+	t.seed ^= t.seed >> 17
+	t.seed ^= t.seed << 5
+	return &statusNode{item: item, priority: t.seed}
+}
+
+// split divides the treap into the items for which before returns true, which must come first,
+// and the remaining ones
+func split(n *statusNode, before func(item int) bool) (left, right *statusNode) {
+	if n == nil {
+		return nil, nil
+	}
+	if before(n.item) {
+		n.right, right = split(n.right, before)
+		return n, right
+	}
+	left, n.left = split(n.left, before)
+	return left, n
+}
+
+// merge joins two treaps, every item of the left one coming before the items of the right one
+func merge(left, right *statusNode) *statusNode {
+	switch {
+	case left == nil:
+		return right
+	case right == nil:
+		return left
+	case left.priority >= right.priority:
+		left.right = merge(left.right, right)
+		return left
+	default:
+		right.left = merge(left, right.left)
+		return right
+	}
+}
+
+// first returns the first item of the treap. Returns false if it is empty
+func first(n *statusNode) (int, bool) {
+	if n == nil {
+		return 0, false
+	}
+	for n.left != nil {
+		n = n.left
+	}
+	return n.item, true
+}
+
+// last returns the last item of the treap. Returns false if it is empty
+func last(n *statusNode) (int, bool) {
+	if n == nil {
+		return 0, false
+	}
+	for n.right != nil {
+		n = n.right
+	}
+	return n.item, true
+}
+
+// removeFirst returns the treap without its first item
+func removeFirst(n *statusNode) *statusNode {
+	if n.left == nil {
+		return n.right
+	}
+	n.left = removeFirst(n.left)
+	return n
+}
+
+// removeLast returns the treap without its last item
+func removeLast(n *statusNode) *statusNode {
+	if n.right == nil {
+		return n.left
+	}
+	n.right = removeLast(n.right)
+	return n
+}
+
+// removeItem returns the treap without the item, searching for it in order, from the first one.
+// Returns false if the item is not in the treap
+func removeItem(n *statusNode, item int) (*statusNode, bool) {
+	if n == nil {
+		return nil, false
+	}
+	if left, ok := removeItem(n.left, item); ok {
+		n.left = left
+		return n, true
+	}
+	if n.item == item {
+		return merge(n.left, n.right), true
+	}
+	if right, ok := removeItem(n.right, item); ok {
+		n.right = right
+		return n, true
+	}
+	return n, false
+}
@@ -1,156 +1,205 @@
 package sweepline
 
 import (
-	"github.com/mindera-gaming/go-data-structure/comparator"
-	"github.com/mindera-gaming/go-data-structure/navigableset"
-	"github.com/mindera-gaming/go-data-structure/queue"
+	"math"
+	"sort"
+
 	"github.com/mindera-gaming/go-math/vector2"
 )
 
-// Using a Sweep-Line Algorithm for segment intersection, based on the pseudo-code found here:
-// Text: https://web.archive.org/web/20141211224415/http://www.lems.brown.edu/~wq/projects/cs252.html
-// Video: https://youtu.be/I9EsN2DTnN8
+// Using the Bentley-Ottmann sweep-line algorithm for segment intersection, as described in:
+// Computational Geometry: Algorithms and Applications (de Berg et al.), chapter 2
+// The sweep-line moves from left to right, and the status holds the segments crossing it from bottom to top.
 
-var queueComparator = func(v1, v2 interface{}) comparator.Result {
-	value1 := v1.(event).Value
-	value2 := v2.(event).Value
-	if value1 < value2 {
-		return comparator.Less
-	}
-	if value1 > value2 {
-		return comparator.Greater
-	}
-	return comparator.Equal
+// relativeTolerance is the distance, relative to the magnitude of the coordinates, below which two points coincide
+// and a point is considered to lie on a segment, absorbing the rounding errors of the calculated intersection points
+const relativeTolerance = 1e-10
+
+// EndpointPolicy defines an enumerator that represents how the segments touching at their endpoints are reported
+type EndpointPolicy byte
+
+const (
+	// ReportSharedEndpoints reports the points where segments only touch at their endpoints
+	ReportSharedEndpoints EndpointPolicy = iota
+	// IgnoreSharedEndpoints ignores the points which are an endpoint of every segment passing through them,
+	// such as the vertices shared by consecutive edges of a polygon
+	IgnoreSharedEndpoints
+)
+
+// Intersection defines a point, or a sub-segment, where two or more segments intersect
+type Intersection struct {
+	Point    vector2.Vector2
+	End      vector2.Vector2 // end of the shared sub-segment of overlapping segments, equal to Point otherwise
+	Segments []int           // indices of the intersecting segments, in ascending order
 }
 
-var navigableComparator = func(v1, v2 interface{}) comparator.Result {
-	value1 := v1.(Segment).Value
-	value2 := v2.(Segment).Value
-	if value1 > value2 {
-		return comparator.Less
-	}
-	if value1 < value2 {
-		return comparator.Greater
-	}
-	return comparator.Equal
+// IsOverlap determines whether the intersection is a sub-segment shared by overlapping collinear segments
+func (i Intersection) IsOverlap() bool {
+	return i.Point != i.End
+}
+
+// Options is the structure that defines the intersection search options
+type Options struct {
+	StopAtFirst bool           // set to true to stop the search at the first intersection found
+	Endpoints   EndpointPolicy // defines how the segments touching at their endpoints are reported
+}
+
+// sweep holds the state of the sweep-line algorithm
+type sweep struct {
+	segments      []Segment
+	options       Options
+	queue         EventQueue
+	events        map[[2]int64][]*event // queued and handled events, hashed by their tolerance cell
+	tolerance     float64               // distance below which two points coincide
+	status        Status
+	intersections []Intersection
 }
 
 // FindIntersections finds and returns the intersections
 func FindIntersections(inputData []Segment) (intersections []vector2.Vector2) {
-	// initialising the event queue
+	for _, intersection := range FindSegmentIntersections(inputData, Options{}) {
+		intersections = append(intersections, intersection.Point)
+	}
+	return
+}
 
-	eventQueue := queue.New(queueComparator)
-	for _, segment := range inputData {
-		eventQueue.Add(NewEventSingleSegment(segment.First(), segment, start))
-		eventQueue.Add(NewEventSingleSegment(segment.Second(), segment, end))
+// FindSegmentIntersections finds the points where two or more segments intersect, including vertical segments and
+// several segments meeting at a single point. Overlapping collinear segments are reported as the sub-segments they
+// share, from the point where the overlap starts until one of them ends. Segments with both endpoints at the same
+// point are ignored.
+// Returns the intersections from left to right, each holding the indices of all segments passing through it.
+func FindSegmentIntersections(segments []Segment, options Options) []Intersection {
+	s := sweep{
+		segments: segments,
+		options:  options,
+		events:   make(map[[2]int64][]*event, 2*len(segments)),
 	}
 
-	// initialising the sweep-line status
-	status, _ := navigableset.New(navigableComparator)
+	// the tolerance is relative to the magnitude of the coordinates
+	magnitude := 1.0
+	for _, segment := range segments {
+		magnitude = math.Max(magnitude, math.Max(math.Max(math.Abs(segment.A.X), math.Abs(segment.A.Y)),
+			math.Max(math.Abs(segment.B.X), math.Abs(segment.B.Y))))
+	}
+	s.tolerance = relativeTolerance * magnitude
+
+	// initialising the event queue
+	for i, segment := range segments {
+		if segment.A == segment.B {
+			continue
+		}
+		first := s.event(segment.First())
+		first.Upper = append(first.Upper, i)
+		s.event(segment.Second())
+	}
 
 	// runs the queue until it is empty
-	for eventQueue.Len() != 0 {
-		e := eventQueue.Poll().(event)
-		l := e.Value
-
-		// handling an event
-		switch e.Type {
-		case start:
-			for _, s := range e.Segments {
-				recalculate(&status, l)
-				status.Add(s)
-
-				lower := status.Lower(s)
-				higher := status.Higher(s)
-
-				if lower != nil {
-					reportIntersection(&eventQueue, lower.(Segment), s, l)
-				}
-				if higher != nil {
-					reportIntersection(&eventQueue, higher.(Segment), s, l)
-				}
-				if lower != nil && higher != nil {
-					removeFuture(&eventQueue, lower.(Segment), higher.(Segment))
-				}
-			}
-		case end:
-			for _, s := range e.Segments {
-				lower := status.Lower(s)
-				higher := status.Higher(s)
+	for s.queue.Len() != 0 {
+		e := s.queue.Pop().Data.(*event)
+		e.handled = true
+		if s.handle(e) && options.StopAtFirst {
+			break
+		}
+	}
+	return s.intersections
+}
 
-				if lower != nil && higher != nil {
-					reportIntersection(&eventQueue, lower.(Segment), higher.(Segment), l)
+// event returns the event of the point p, or of a point coinciding with it, adding it to the queue if needed.
+// Points calculated from different pairs of segments may differ by their rounding errors, so they share an event.
+func (s *sweep) event(p vector2.Vector2) *event {
+	x := int64(math.Floor(p.X / s.tolerance))
+	y := int64(math.Floor(p.Y / s.tolerance))
+	for i := x - 1; i <= x+1; i++ {
+		for j := y - 1; j <= y+1; j++ {
+			for _, e := range s.events[[2]int64{i, j}] {
+				if s.coincide(e.Point, p) {
+					return e
 				}
-				status.Remove(s)
 			}
-		case intersection:
-			s1 := e.Segments[0]
-			s2 := e.Segments[1]
-
-			swap(&status, s1, s2)
-
-			if s1.Value < s2.Value {
-				s1Higher := status.Higher(s1)
-				s2Lower := status.Lower(s2)
+		}
+	}
 
-				if s1Higher != nil {
-					reportIntersection(&eventQueue, s1Higher.(Segment), s1, l)
-					removeFuture(&eventQueue, s1Higher.(Segment), s2)
-				}
-				if s2Lower != nil {
-					reportIntersection(&eventQueue, s2Lower.(Segment), s2, l)
-					removeFuture(&eventQueue, s2Lower.(Segment), s1)
-				}
-			} else {
-				s2Higher := status.Higher(s2)
-				s1Lower := status.Lower(s1)
+	e := &event{Point: p}
+	s.events[[2]int64{x, y}] = append(s.events[[2]int64{x, y}], e)
+	s.queue.Push(p, e)
+	return e
+}
 
-				if s2Higher != nil {
-					reportIntersection(&eventQueue, s2Higher.(Segment), s2, l)
-					removeFuture(&eventQueue, s2Higher.(Segment), s1)
-				}
-				if s1Lower != nil {
-					reportIntersection(&eventQueue, s1Lower.(Segment), s1, l)
-					removeFuture(&eventQueue, s1Lower.(Segment), s2)
-				}
-			}
+// handle processes an event point, updating the status and looking for new intersections.
+// Returns true if an intersection was found at the event point.
+func (s *sweep) handle(e *event) (found bool) {
+	p := e.Point
+	below, passing, above := s.containing(e)
 
-			// adds the new intersection
-			intersections = append(intersections, e.Point)
+	// the segments ending at p leave the status, the ones passing through it are reinserted in their new order
+	var continuing []int
+	involved := append([]int{}, e.Upper...)
+	for _, i := range passing {
+		involved = append(involved, i)
+		if !s.coincide(s.segments[i].Second(), p) {
+			continuing = append(continuing, i)
 		}
 	}
-	return
-}
-
-// reportIntersection reports an intersection point and its involved segments
-func reportIntersection(queue *queue.Queue, s1, s2 Segment, l float64) bool {
-	x1 := s1.First().X
-	y1 := s1.First().Y
+	continuing = append(continuing, e.Upper...)
+	sort.Ints(involved)
 
-	x2 := s1.Second().X
-	y2 := s1.Second().Y
+	// the order of the segments just after p is given by their slopes, vertical segments being the last ones
+	sort.SliceStable(continuing, func(a, b int) bool {
+		sa := s.segments[continuing[a]]
+		sb := s.segments[continuing[b]]
+		return !sa.isParallel(sb) && sa.direction().Cross(sb.direction()) > 0
+	})
 
-	x3 := s2.First().X
-	y3 := s2.First().Y
+	if s.touches(involved, p) {
+		s.intersections = append(s.intersections, Intersection{Point: p, End: p, Segments: involved})
+		found = true
+	}
+	if s.overlaps(e, continuing, involved) {
+		found = true
+	}
 
-	x4 := s2.Second().X
-	y4 := s2.Second().Y
+	// the neighbours are found before merging, as it relinks the nodes
+	lower, hasLower := last(below)
+	upper, hasUpper := first(above)
+	var middle *statusNode
+	for _, i := range continuing {
+		middle = merge(middle, s.status.newNode(i))
+	}
+	s.status.root = merge(merge(below, middle), above)
 
-	r := (x2-x1)*(y4-y3) - (y2-y1)*(x4-x3)
-	if r != 0 {
-		t := ((x3-x1)*(y4-y3) - (y3-y1)*(x4-x3)) / r
-		u := ((x3-x1)*(y2-y1) - (y3-y1)*(x2-x1)) / r
+	if len(continuing) == 0 {
+		if hasLower && hasUpper {
+			s.findEvent(lower, upper, p)
+		}
+		return
+	}
+	if hasLower {
+		s.findEvent(lower, continuing[0], p)
+	}
+	if hasUpper {
+		s.findEvent(continuing[len(continuing)-1], upper, p)
+	}
+	return
+}
 
-		if t >= 0 && t <= 1 && u >= 0 && u <= 1 {
-			xC := x1 + t*(x2-x1)
-			yC := y1 + t*(y2-y1)
+// touches determines whether two of the segments passing through the point p intersect at that single point,
+// following the endpoint policy
+func (s *sweep) touches(involved []int, p vector2.Vector2) bool {
+	for i, a := range involved {
+		sa := s.segments[a]
+		for _, b := range involved[i+1:] {
+			sb := s.segments[b]
 
-			if xC > l {
-				queue.Add(NewEvent(
-					vector2.Vector2{X: xC, Y: yC},
-					[]Segment{s1, s2},
-					intersection,
-				))
+			if sa.isParallel(sb) {
+				// collinear segments overlap, unless one ends where the other starts
+				if (s.coincide(sa.Second(), p) && s.coincide(sb.First(), p)) || (s.coincide(sa.First(), p) && s.coincide(sb.Second(), p)) {
+					if s.options.Endpoints == ReportSharedEndpoints {
+						return true
+					}
+				}
+				continue
+			}
+			if s.options.Endpoints == ReportSharedEndpoints || !s.hasEndpoint(sa, p) || !s.hasEndpoint(sb, p) {
 				return true
 			}
 		}
@@ -158,38 +207,104 @@ func reportIntersection(queue *queue.Queue, s1, s2 Segment, l float64) bool {
 	return false
 }
 
-// removeFuture removes future segments from the queue
-func removeFuture(queue *queue.Queue, s1, s2 Segment) bool {
-	for e := queue.List.Front(); e != nil; e = e.Next() {
-		event := e.Value.(event)
-		if event.Type == intersection {
-			if (event.Segments[0] == s1 && event.Segments[1] == s2) || (event.Segments[0] == s2 && event.Segments[1] == s1) {
-				queue.Remove(e)
-				return true
+// overlaps reports the collinear segments overlapping from the event point onwards, given the segments which
+// continue after the event point sorted by their slopes. An overlap is only reported when it starts or when
+// the set of overlapping segments changes.
+// Returns true if an overlap was reported.
+func (s *sweep) overlaps(e *event, continuing, involved []int) (found bool) {
+	for start := 0; start < len(continuing); {
+		end := start + 1
+		for end < len(continuing) && s.segments[continuing[start]].isParallel(s.segments[continuing[end]]) {
+			end++
+		}
+		group := continuing[start:end]
+		start = end
+		if len(group) < 2 || !s.changes(e, group, involved) {
+			continue
+		}
+
+		// the overlap lasts until the first segment of the group ends
+		last := s.segments[group[0]].Second()
+		for _, i := range group[1:] {
+			if q := s.segments[i].Second(); precedes(q, last) {
+				last = q
 			}
 		}
+
+		segments := append([]int{}, group...)
+		sort.Ints(segments)
+		s.intersections = append(s.intersections, Intersection{Point: e.Point, End: last, Segments: segments})
+		found = true
+	}
+	return
+}
+
+// changes determines whether a group of overlapping segments starts at the event point, either because one of
+// them starts there or because a collinear segment ends there
+func (s *sweep) changes(e *event, group, involved []int) bool {
+	for _, i := range group {
+		if s.coincide(s.segments[i].First(), e.Point) {
+			return true
+		}
+	}
+	for _, i := range involved {
+		segment := s.segments[i]
+		if s.coincide(segment.Second(), e.Point) && segment.isParallel(s.segments[group[0]]) {
+			return true
+		}
 	}
 	return false
 }
 
-// swap switches two segments of the status list
-func swap(status *navigableset.NavigableSet, s1, s2 Segment) {
-	status.Remove(s1)
-	status.Remove(s2)
-
-	tempValue := s1.Value
-	s1.Value = s2.Value
-	s2.Value = tempValue
+// containing takes the segments which pass through the event point out of the status, from bottom to top.
+// Returns them along with the parts of the status below and above them
+func (s *sweep) containing(e *event) (below *statusNode, passing []int, above *statusNode) {
+	p := e.Point
+	passes := func(i int) bool {
+		segment := s.segments[i]
+		return e.isCrossing(i) || s.coincide(segment.Second(), p) || segment.distance(p) <= s.tolerance
+	}
 
-	status.Add(s1)
-	status.Add(s2)
+	below, above = split(s.status.root, func(i int) bool {
+		return s.segments[i].orientation(p) > 0
+	})
+	s.status.root = nil
+	for i, ok := last(below); ok && passes(i); i, ok = last(below) {
+		passing = append(passing, i)
+		below = removeLast(below)
+	}
+	for i, j := 0, len(passing)-1; i < j; i, j = i+1, j-1 {
+		passing[i], passing[j] = passing[j], passing[i]
+	}
+	for i, ok := first(above); ok && passes(i); i, ok = first(above) {
+		passing = append(passing, i)
+		above = removeFirst(above)
+	}
+	return
 }
 
-// recalculate recalculates the value of all segments in the status list
-func recalculate(status *navigableset.NavigableSet, line float64) {
-	for e := status.List.Front(); e != nil; e = e.Next() {
-		value := e.Value.(Segment)
-		value.CalculateValue(line)
-		e.Value = value
+// findEvent adds the intersection of two segments to the queue, if it lies after the point p
+func (s *sweep) findEvent(a, b int, p vector2.Vector2) {
+	q, ok := s.segments[a].intersect(s.segments[b], s.tolerance)
+	if !ok || !precedes(p, q) {
+		return
+	}
+
+	e := s.event(q)
+	if e.handled {
+		// the intersection coincides with a point which was already handled
+		return
 	}
+	e.addCrossing(a)
+	e.addCrossing(b)
+}
+
+// coincide determines whether the point q coincides with the point p, within the rounding errors
+func (s *sweep) coincide(q, p vector2.Vector2) bool {
+	return q == p || q.Distance(p) <= s.tolerance
+}
+
+// hasEndpoint determines whether the point p coincides with one of the endpoints of the segment
+func (s *sweep) hasEndpoint(segment Segment, p vector2.Vector2) bool {
+	return s.coincide(segment.A, p) || s.coincide(segment.B, p)
 }
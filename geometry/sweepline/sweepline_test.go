@@ -0,0 +1,256 @@
+package sweepline
+
+import (
+	"math/rand"
+	"testing"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// pairKind defines how two segments intersect, as found by the brute-force search
+type pairKind byte
+
+const (
+	disjoint    pairKind = iota
+	touching             // the segments share a single point
+	overlapping          // the segments are collinear and share a sub-segment
+)
+
+// bruteForcePair determines how two segments with integer coordinates intersect, with exact arithmetic.
+// Returns the shared point of touching segments, or the start of the shared sub-segment of overlapping ones
+func bruteForcePair(a, b Segment) (pairKind, vector.Vector2) {
+	orientation := func(p, q, r vector.Vector2) float64 {
+		return p.To(q).Cross(p.To(r))
+	}
+	within := func(p, q, r vector.Vector2) bool {
+		return !precedes(r, p) && !precedes(q, r)
+	}
+
+	a1, a2, b1, b2 := a.First(), a.Second(), b.First(), b.Second()
+	d1 := orientation(b1, b2, a1)
+	d2 := orientation(b1, b2, a2)
+	d3 := orientation(a1, a2, b1)
+	d4 := orientation(a1, a2, b2)
+
+	if d1 == 0 && d2 == 0 {
+		// collinear segments share the interval between the latest start and the earliest end
+		start, end := a1, a2
+		if precedes(start, b1) {
+			start = b1
+		}
+		if precedes(b2, end) {
+			end = b2
+		}
+		switch {
+		case precedes(end, start):
+			return disjoint, vector.Vector2{}
+		case start == end:
+			return touching, start
+		default:
+			return overlapping, start
+		}
+	}
+
+	crosses := (d1 > 0) != (d2 > 0) && (d3 > 0) != (d4 > 0) && d1 != 0 && d2 != 0 && d3 != 0 && d4 != 0
+	if !crosses {
+		switch {
+		case d1 == 0 && within(b1, b2, a1):
+			return touching, a1
+		case d2 == 0 && within(b1, b2, a2):
+			return touching, a2
+		case d3 == 0 && within(a1, a2, b1):
+			return touching, b1
+		case d4 == 0 && within(a1, a2, b2):
+			return touching, b2
+		default:
+			return disjoint, vector.Vector2{}
+		}
+	}
+
+	r := a.direction()
+	t := a1.To(b1).Cross(b.direction()) / r.Cross(b.direction())
+	return touching, a1.Add(r.Mul(t))
+}
+
+// isSharedEndpoint determines whether the point is an endpoint of both segments
+func isSharedEndpoint(a, b Segment, p vector.Vector2) bool {
+	return (a.A == p || a.B == p) && (b.A == p || b.B == p)
+}
+
+// checkAgainstBruteForce compares the intersections found by the sweep-line with the ones found by testing every
+// pair of segments
+func checkAgainstBruteForce(t *testing.T, segments []Segment, policy EndpointPolicy) {
+	t.Helper()
+	intersections := FindSegmentIntersections(segments, Options{Endpoints: policy})
+
+	reported := make(map[[2]int]bool)
+	for k, intersection := range intersections {
+		if k > 0 && precedes(intersection.Point, intersections[k-1].Point) {
+			t.Fatalf("intersections out of order: %v before %v", intersections[k-1], intersection)
+		}
+		for x, i := range intersection.Segments {
+			for _, j := range intersection.Segments[x+1:] {
+				if j <= i {
+					t.Fatalf("segments of %v out of order", intersection)
+				}
+				kind, p := bruteForcePair(segments[i], segments[j])
+				switch {
+				case kind == disjoint:
+					t.Fatalf("%v holds the disjoint segments %v and %v", intersection, segments[i], segments[j])
+				case intersection.IsOverlap() && kind != overlapping:
+					t.Fatalf("%v holds the non-overlapping segments %v and %v", intersection, segments[i], segments[j])
+				case kind == touching && p.Distance(intersection.Point) > 1e-9:
+					t.Fatalf("%v should lie at %v for %v and %v", intersection, p, segments[i], segments[j])
+				}
+				reported[[2]int{i, j}] = true
+			}
+		}
+	}
+
+	for i := range segments {
+		for j := i + 1; j < len(segments); j++ {
+			kind, p := bruteForcePair(segments[i], segments[j])
+			if kind == disjoint || reported[[2]int{i, j}] {
+				continue
+			}
+			if kind == touching && policy == IgnoreSharedEndpoints && isSharedEndpoint(segments[i], segments[j], p) {
+				continue
+			}
+			t.Fatalf("missing intersection of %v and %v in %v", segments[i], segments[j], intersections)
+		}
+	}
+
+	// the search stops after the first event point holding an intersection
+	first := FindSegmentIntersections(segments, Options{Endpoints: policy, StopAtFirst: true})
+	if (len(first) == 0) != (len(intersections) == 0) || len(first) > len(intersections) {
+		t.Fatalf("stopping at the first intersection found %v, while the full search found %v", first, intersections)
+	}
+	for k := range first {
+		if first[k].Point != intersections[0].Point || first[k].Point != intersections[k].Point {
+			t.Fatalf("stopping at the first intersection found %v, while the full search found %v", first, intersections)
+		}
+	}
+}
+
+// randomSegments returns segments between the points of a small integer grid, so that vertical segments,
+// shared endpoints and collinear overlaps are frequent
+func randomSegments(r *rand.Rand, n, size int) []Segment {
+	point := func() vector.Vector2 {
+		return vector.Vector2{X: float64(r.Intn(size)), Y: float64(r.Intn(size))}
+	}
+	segments := make([]Segment, 0, n)
+	for len(segments) < n {
+		if a, b := point(), point(); a != b {
+			segments = append(segments, NewSegment(a, b))
+		}
+	}
+	return segments
+}
+
+func TestFindSegmentIntersectionsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iteration := 0; iteration < 5000; iteration++ {
+		segments := randomSegments(r, 2+r.Intn(20), 2+r.Intn(8))
+		checkAgainstBruteForce(t, segments, ReportSharedEndpoints)
+		checkAgainstBruteForce(t, segments, IgnoreSharedEndpoints)
+	}
+}
+
+func TestFindSegmentIntersectionsRandomLarge(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for iteration := 0; iteration < 20; iteration++ {
+		segments := randomSegments(r, 200+r.Intn(300), 50+r.Intn(200))
+		checkAgainstBruteForce(t, segments, ReportSharedEndpoints)
+		checkAgainstBruteForce(t, segments, IgnoreSharedEndpoints)
+	}
+}
+
+func TestFindSegmentIntersectionsDegenerate(t *testing.T) {
+	v := func(x, y float64) vector.Vector2 {
+		return vector.Vector2{X: x, Y: y}
+	}
+	tests := []struct {
+		name     string
+		segments []Segment
+	}{
+		{"collinear overlap", []Segment{NewSegment(v(0, 0), v(4, 4)), NewSegment(v(2, 2), v(6, 6))}},
+		{"collinear containment", []Segment{NewSegment(v(0, 0), v(6, 0)), NewSegment(v(2, 0), v(3, 0))}},
+		{"collinear touching", []Segment{NewSegment(v(0, 0), v(2, 1)), NewSegment(v(2, 1), v(4, 2))}},
+		{"identical segments", []Segment{NewSegment(v(0, 0), v(3, 1)), NewSegment(v(3, 1), v(0, 0))}},
+		{"shared endpoint", []Segment{NewSegment(v(0, 0), v(2, 2)), NewSegment(v(2, 2), v(4, 0))}},
+		{"star", []Segment{
+			NewSegment(v(0, 0), v(2, 2)), NewSegment(v(4, 4), v(2, 2)), NewSegment(v(2, 0), v(2, 2)),
+			NewSegment(v(2, 4), v(2, 2)), NewSegment(v(0, 2), v(4, 2)),
+		}},
+		{"vertical crossing", []Segment{NewSegment(v(1, -1), v(1, 3)), NewSegment(v(0, 0), v(3, 2))}},
+		{"vertical overlap", []Segment{NewSegment(v(1, 0), v(1, 3)), NewSegment(v(1, 2), v(1, 5))}},
+		{"vertical touching", []Segment{NewSegment(v(1, 0), v(1, 3)), NewSegment(v(1, 3), v(1, 5))}},
+		{"endpoint on interior", []Segment{NewSegment(v(0, 0), v(4, 0)), NewSegment(v(2, 0), v(3, 3))}},
+		{"vertical endpoint on interior", []Segment{NewSegment(v(2, -2), v(2, 2)), NewSegment(v(2, 0), v(5, 1))}},
+		{"square", []Segment{
+			NewSegment(v(0, 0), v(2, 0)), NewSegment(v(2, 0), v(2, 2)), NewSegment(v(2, 2), v(0, 2)),
+			NewSegment(v(0, 2), v(0, 0)),
+		}},
+		{"chained overlaps", []Segment{
+			NewSegment(v(0, 0), v(3, 0)), NewSegment(v(1, 0), v(5, 0)), NewSegment(v(2, 0), v(4, 0)),
+			NewSegment(v(2, -1), v(2, 1)),
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkAgainstBruteForce(t, test.segments, ReportSharedEndpoints)
+			checkAgainstBruteForce(t, test.segments, IgnoreSharedEndpoints)
+		})
+	}
+}
+
+func TestFindSegmentIntersectionsPoints(t *testing.T) {
+	v := func(x, y float64) vector.Vector2 {
+		return vector.Vector2{X: x, Y: y}
+	}
+	segments := []Segment{
+		NewSegment(v(0, 0), v(4, 4)),
+		NewSegment(v(0, 4), v(4, 0)),
+		NewSegment(v(2, -1), v(2, 5)),
+		NewSegment(v(3, 0), v(3, 2)),
+		NewSegment(v(3, 2), v(5, 2)),
+	}
+	want := []Intersection{
+		{Point: v(2, 2), End: v(2, 2), Segments: []int{0, 1, 2}},
+		{Point: v(3, 1), End: v(3, 1), Segments: []int{1, 3}},
+		{Point: v(3, 2), End: v(3, 2), Segments: []int{3, 4}},
+	}
+
+	got := FindSegmentIntersections(segments, Options{})
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for k := range want {
+		if got[k].Point != want[k].Point || got[k].End != want[k].End || len(got[k].Segments) != len(want[k].Segments) {
+			t.Fatalf("got %v, want %v", got[k], want[k])
+		}
+		for x := range want[k].Segments {
+			if got[k].Segments[x] != want[k].Segments[x] {
+				t.Fatalf("got %v, want %v", got[k], want[k])
+			}
+		}
+	}
+
+	ignored := FindSegmentIntersections(segments, Options{Endpoints: IgnoreSharedEndpoints})
+	if len(ignored) != 2 {
+		t.Fatalf("the shared endpoint (3, 2) should be ignored, got %v", ignored)
+	}
+}
+
+func BenchmarkFindSegmentIntersections(b *testing.B) {
+	r := rand.New(rand.NewSource(3))
+	segments := make([]Segment, 10000)
+	for i := range segments {
+		a := vector.Vector2{X: r.Float64() * 1000, Y: r.Float64() * 1000}
+		segments[i] = NewSegment(a, a.Add(vector.Vector2{X: r.Float64()*20 - 10, Y: r.Float64()*20 - 10}))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		FindSegmentIntersections(segments, Options{})
+	}
+}
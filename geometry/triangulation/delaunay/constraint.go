@@ -0,0 +1,364 @@
+package delaunay
+
+import (
+	"github.com/mindera-gaming/go-math/geometry"
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// TriangulationOptions is the structure that defines the triangulation options.
+type TriangulationOptions struct {
+	SkipSimplePolygonValidation bool // set to true to skip the simple polygon verification
+}
+
+// TriangulatePolygon computes the constrained Delaunay triangulation of a simple polygon.
+//
+// Receives the set of vertices of a polygon and the triangulation options.
+// Returns the set of indices of the vertices of the calculated triangles, in clockwise order.
+func TriangulatePolygon(vertices []vector.Vector2, options TriangulationOptions) (triangles []int, err error) {
+	t, err := NewPolygon(vertices, options)
+	if err != nil {
+		return
+	}
+	return t.Triangles, nil
+}
+
+// NewPolygon computes the constrained Delaunay triangulation of a simple polygon.
+//
+// The Delaunay triangulation of the vertices is computed first, then every edge of the polygon is inserted
+// as a constraint and the triangles outside the polygon are discarded.
+func NewPolygon(vertices []vector.Vector2, options TriangulationOptions) (t *Triangulation, err error) {
+	if vertices == nil {
+		err = ErrNilVertices
+		return
+	}
+	if len(vertices) < 3 {
+		err = ErrInsufficientVertices
+		return
+	}
+	if !options.SkipSimplePolygonValidation {
+		if !geometry.IsSimplePolygon(vertices) {
+			err = ErrNotSimplePolygon
+			return
+		}
+	}
+
+	if t, err = New(vertices); err != nil {
+		return
+	}
+	for i := range vertices {
+		if err = t.InsertConstraint(i, (i+1)%len(vertices)); err != nil {
+			t = nil
+			return
+		}
+	}
+
+	t.removeOuterTriangles()
+	return
+}
+
+// IsConstrained determines whether the half-edge e is part of a constrained edge
+func (t *Triangulation) IsConstrained(e int) bool {
+	return t.constraints[edgeKey(t.Triangles[e], t.Triangles[NextHalfEdge(e)])]
+}
+
+// Neighbours returns the triangles sharing an edge with the given triangle
+func (t *Triangulation) Neighbours(triangle int) (neighbours []int) {
+	for e := 3 * triangle; e < 3*triangle+3; e++ {
+		if opposite := t.HalfEdges[e]; opposite != -1 {
+			neighbours = append(neighbours, TriangleOfEdge(opposite))
+		}
+	}
+	return
+}
+
+// InsertConstraint forces the edge between the vertices a and b into the triangulation.
+//
+// The edges crossed by the constraint are flipped until none is left, and the new edges are then flipped
+// back until they satisfy the Delaunay condition, without ever flipping a constrained edge.
+// If the constraint passes through other vertices, it is split at each of them.
+func (t *Triangulation) InsertConstraint(a, b int) error {
+	if a < 0 || b < 0 || a >= len(t.Points) || b >= len(t.Points) || a == b ||
+		t.vertexEdge[a] == -1 || t.vertexEdge[b] == -1 {
+		return ErrInvalidConstraint
+	}
+
+	for a != b {
+		crossing, end, err := t.findCrossingEdges(a, b)
+		if err != nil {
+			return err
+		}
+
+		if len(crossing) > 0 {
+			if err = t.removeCrossingEdges(a, end, crossing); err != nil {
+				return err
+			}
+		}
+		t.constraints[edgeKey(a, end)] = true
+		a = end
+	}
+	return nil
+}
+
+// findCrossingEdges walks the triangles from the vertex a towards the vertex b and collects the edges crossed.
+// The walk stops at b or at the first vertex lying on the segment ab.
+// Returns the crossed edges and the vertex where the walk stopped.
+func (t *Triangulation) findCrossingEdges(a, b int) (crossing [][2]int, end int, err error) {
+	pa := t.Points[a]
+	pb := t.Points[b]
+
+	// looking for the triangle around a which contains the direction of b
+	edge := -1
+	end = -1
+	t.forEachOutgoingEdge(a, func(e int) bool {
+		x := t.Triangles[NextHalfEdge(e)]
+		y := t.Triangles[PrevHalfEdge(e)]
+		if x == b || y == b {
+			// the edge already exists
+			end = b
+			return false
+		}
+
+		px := t.Points[x]
+		py := t.Points[y]
+		ox := predicates.Orient2D(pa, pb, px)
+		oy := predicates.Orient2D(pa, pb, py)
+		if ox == 0 && px.To(pa).Dot(px.To(pb)) < 0 {
+			end = x
+			return false
+		}
+		if oy == 0 && py.To(pa).Dot(py.To(pb)) < 0 {
+			end = y
+			return false
+		}
+		// the triangle is clockwise, so it contains the direction of b if x lies to its left and y to its right
+		if ox > 0 && oy < 0 {
+			edge = NextHalfEdge(e)
+			return false
+		}
+		return true
+	})
+	if end != -1 {
+		return nil, end, nil
+	}
+	if edge == -1 {
+		return nil, 0, ErrInvalidConstraint
+	}
+
+	// walking through the triangles crossed by the segment, every crossed half-edge goes from left to right
+	for {
+		if t.IsConstrained(edge) {
+			return nil, 0, ErrIntersectingConstraints
+		}
+		crossing = append(crossing, [2]int{t.Triangles[edge], t.Triangles[NextHalfEdge(edge)]})
+
+		opposite := t.HalfEdges[edge]
+		if opposite == -1 {
+			return nil, 0, ErrInvalidConstraint
+		}
+		z := t.Triangles[PrevHalfEdge(opposite)]
+		if z == b {
+			return crossing, b, nil
+		}
+
+		oz := predicates.Orient2D(pa, pb, t.Points[z])
+		if oz == 0 {
+			return crossing, z, nil
+		}
+		if oz > 0 {
+			edge = PrevHalfEdge(opposite)
+		} else {
+			edge = NextHalfEdge(opposite)
+		}
+	}
+}
+
+// removeCrossingEdges flips the edges crossing the segment ab until none is left, then restores the
+// Delaunay condition on the new edges.
+//
+// As flips move edges between half-edges, the pending edges are kept as pairs of vertices.
+func (t *Triangulation) removeCrossingEdges(a, b int, crossing [][2]int) error {
+	pa := t.Points[a]
+	pb := t.Points[b]
+
+	var created [][2]int
+	for attempts := 0; len(crossing) > 0; attempts++ {
+		if attempts > len(t.Triangles)*len(t.Triangles) {
+			return ErrInvalidConstraint
+		}
+
+		edge := crossing[0]
+		crossing = crossing[1:]
+		e := t.findEdge(edge[0], edge[1])
+
+		// the quadrilateral must be strictly convex for the flip to be valid
+		opposite := t.HalfEdges[e]
+		p0 := t.Points[t.Triangles[PrevHalfEdge(e)]]
+		pr := t.Points[t.Triangles[e]]
+		pl := t.Points[t.Triangles[NextHalfEdge(e)]]
+		p1 := t.Points[t.Triangles[PrevHalfEdge(opposite)]]
+		if !segmentsCross(p0, p1, pr, pl) {
+			crossing = append(crossing, edge)
+			continue
+		}
+
+		diagonal := t.flip(e)
+		v0 := t.Triangles[diagonal]
+		v1 := t.Triangles[NextHalfEdge(diagonal)]
+		if v0 != a && v0 != b && v1 != a && v1 != b && segmentsCross(t.Points[v0], t.Points[v1], pa, pb) {
+			crossing = append(crossing, [2]int{v0, v1})
+		} else {
+			created = append(created, [2]int{v0, v1})
+		}
+	}
+
+	// restoring the Delaunay condition
+	key := edgeKey(a, b)
+	for flipped := true; flipped; {
+		flipped = false
+		for i, edge := range created {
+			if edgeKey(edge[0], edge[1]) == key || t.constraints[edgeKey(edge[0], edge[1])] {
+				continue
+			}
+			e := t.findEdge(edge[0], edge[1])
+			if !t.isIllegal(e) {
+				continue
+			}
+			diagonal := t.flip(e)
+			created[i] = [2]int{t.Triangles[diagonal], t.Triangles[NextHalfEdge(diagonal)]}
+			flipped = true
+		}
+	}
+	return nil
+}
+
+// forEachOutgoingEdge calls the function for every half-edge leaving the vertex v,
+// stopping as soon as the function returns false
+func (t *Triangulation) forEachOutgoingEdge(v int, f func(e int) bool) {
+	start := t.vertexEdge[v]
+	if start == -1 {
+		return
+	}
+
+	// rotating around the vertex until reaching the start again or the boundary
+	e := start
+	for {
+		if !f(e) {
+			return
+		}
+		opposite := t.HalfEdges[PrevHalfEdge(e)]
+		if opposite == -1 {
+			break
+		}
+		if e = opposite; e == start {
+			return
+		}
+	}
+
+	// rotating in the other direction from the start
+	e = start
+	for {
+		opposite := t.HalfEdges[e]
+		if opposite == -1 {
+			return
+		}
+		e = NextHalfEdge(opposite)
+		if !f(e) {
+			return
+		}
+	}
+}
+
+// findEdge returns a half-edge between the vertices u and v, or -1 if they are not connected
+func (t *Triangulation) findEdge(u, v int) (edge int) {
+	edge = -1
+	t.forEachOutgoingEdge(u, func(e int) bool {
+		if t.Triangles[NextHalfEdge(e)] == v {
+			edge = e
+		} else if t.Triangles[PrevHalfEdge(e)] == v {
+			edge = PrevHalfEdge(e)
+		}
+		return edge == -1
+	})
+	return
+}
+
+// removeOuterTriangles discards the triangles which can be reached from the boundary without crossing a
+// constrained edge, and rebuilds the half-edge adjacency of the remaining ones
+func (t *Triangulation) removeOuterTriangles() {
+	triangleCount := len(t.Triangles) / 3
+	outer := make([]bool, triangleCount)
+
+	var stack []int
+	for e, opposite := range t.HalfEdges {
+		if opposite == -1 && !t.IsConstrained(e) && !outer[TriangleOfEdge(e)] {
+			outer[TriangleOfEdge(e)] = true
+			stack = append(stack, TriangleOfEdge(e))
+		}
+	}
+	for len(stack) > 0 {
+		triangle := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for e := 3 * triangle; e < 3*triangle+3; e++ {
+			opposite := t.HalfEdges[e]
+			if opposite == -1 || t.IsConstrained(e) || outer[TriangleOfEdge(opposite)] {
+				continue
+			}
+			outer[TriangleOfEdge(opposite)] = true
+			stack = append(stack, TriangleOfEdge(opposite))
+		}
+	}
+
+	// compacting the remaining triangles
+	remap := make([]int, triangleCount)
+	count := 0
+	for triangle := range remap {
+		remap[triangle] = -1
+		if !outer[triangle] {
+			remap[triangle] = count
+			count++
+		}
+	}
+
+	triangles := make([]int, 0, count*3)
+	halfEdges := make([]int, 0, count*3)
+	for i := range t.vertexEdge {
+		t.vertexEdge[i] = -1
+	}
+	for e, v := range t.Triangles {
+		if outer[TriangleOfEdge(e)] {
+			continue
+		}
+		opposite := t.HalfEdges[e]
+		if opposite != -1 {
+			if r := remap[TriangleOfEdge(opposite)]; r == -1 {
+				opposite = -1
+			} else {
+				opposite = r*3 + opposite%3
+			}
+		}
+		t.vertexEdge[v] = len(triangles)
+		triangles = append(triangles, v)
+		halfEdges = append(halfEdges, opposite)
+	}
+	t.Triangles = triangles
+	t.HalfEdges = halfEdges
+}
+
+// edgeKey returns the key of the edge between two vertices, regardless of its direction
+func edgeKey(a, b int) [2]int {
+	if a > b {
+		return [2]int{b, a}
+	}
+	return [2]int{a, b}
+}
+
+// segmentsCross determines whether the segments ab and cd properly cross each other
+func segmentsCross(a, b, c, d vector.Vector2) bool {
+	o1 := predicates.Orient2D(a, b, c)
+	o2 := predicates.Orient2D(a, b, d)
+	o3 := predicates.Orient2D(c, d, a)
+	o4 := predicates.Orient2D(c, d, b)
+
+	return ((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) && ((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0))
+}
@@ -0,0 +1,416 @@
+// Package delaunay provides Delaunay and constrained Delaunay triangulations of point sets and polygons
+package delaunay
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/geometry"
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Triangulation holds a triangulation of a set of points, along with its half-edge adjacency.
+//
+// Every triangle t is stored clockwise in Triangles[3t], Triangles[3t+1] and Triangles[3t+2]. The half-edge e
+// goes from the vertex Triangles[e] to the vertex Triangles[NextHalfEdge(e)], and HalfEdges[e] holds its
+// opposite half-edge in the adjacent triangle, or -1 if e lies on the boundary.
+type Triangulation struct {
+	Points    []vector.Vector2
+	Triangles []int
+	HalfEdges []int
+
+	vertexEdge  []int           // outgoing half-edge of each vertex, or -1 if not triangulated
+	constraints map[[2]int]bool // constrained edges, keyed by their sorted vertex indices
+	hullPrev    []int           // hull of the sweep, only used during construction
+	hullNext    []int           // hull of the sweep, only used during construction
+	hullTri     []int           // boundary half-edge of each hull vertex, only used during construction
+	hullStart   int             // first vertex of the hull, only used during construction
+	edgeStack   []int           // pending edges of the legalisation
+	center      vector.Vector2  // center of the sweep, only used during construction
+	hullHash    []int           // hull vertices hashed by their angle, only used during construction
+}
+
+// NextHalfEdge returns the half-edge following e in its triangle
+func NextHalfEdge(e int) int {
+	if e%3 == 2 {
+		return e - 2
+	}
+	return e + 1
+}
+
+// PrevHalfEdge returns the half-edge preceding e in its triangle
+func PrevHalfEdge(e int) int {
+	if e%3 == 0 {
+		return e + 2
+	}
+	return e - 1
+}
+
+// TriangleOfEdge returns the triangle containing the half-edge e
+func TriangleOfEdge(e int) int {
+	return e / 3
+}
+
+// Triangulate computes the Delaunay triangulation of a set of points.
+//
+// Receives the set of points.
+// Returns the set of indices of the vertices of the calculated triangles, in clockwise order.
+func Triangulate(points []vector.Vector2) (triangles []int, err error) {
+	t, err := New(points)
+	if err != nil {
+		return
+	}
+	return t.Triangles, nil
+}
+
+// New computes the Delaunay triangulation of a set of points, using a radial sweep-hull approach.
+//
+// The points are added in order of distance from a seed triangle, connecting each one to the visible edges
+// of the current hull, and every new edge is legalised with edge flips.
+// Duplicated points are not triangulated.
+func New(points []vector.Vector2) (t *Triangulation, err error) {
+	if points == nil {
+		err = ErrNilVertices
+		return
+	}
+	n := len(points)
+	if n < 3 {
+		err = ErrInsufficientVertices
+		return
+	}
+
+	t = &Triangulation{
+		Points:      points,
+		Triangles:   make([]int, 0, (2*n-5)*3),
+		HalfEdges:   make([]int, 0, (2*n-5)*3),
+		vertexEdge:  make([]int, n),
+		constraints: make(map[[2]int]bool),
+	}
+	for i := range t.vertexEdge {
+		t.vertexEdge[i] = -1
+	}
+	if err = t.sweep(); err != nil {
+		t = nil
+		return
+	}
+
+	// releasing the sweep structures
+	t.hullPrev = nil
+	t.hullNext = nil
+	t.hullTri = nil
+	t.hullHash = nil
+	return
+}
+
+// sweep adds every point to the triangulation
+func (t *Triangulation) sweep() error {
+	points := t.Points
+	n := len(points)
+
+	min := points[0]
+	max := points[0]
+	for _, p := range points {
+		min.X = math.Min(min.X, p.X)
+		min.Y = math.Min(min.Y, p.Y)
+		max.X = math.Max(max.X, p.X)
+		max.Y = math.Max(max.Y, p.Y)
+	}
+	center := vector.Lerp(min, max, 0.5)
+
+	// picking a seed point close to the center
+	i0, i1, i2 := -1, -1, -1
+	minDistance := math.Inf(1)
+	for i, p := range points {
+		if d := center.DistanceSqr(p); d < minDistance {
+			i0 = i
+			minDistance = d
+		}
+	}
+	// finding the point closest to the seed
+	minDistance = math.Inf(1)
+	for i, p := range points {
+		if d := points[i0].DistanceSqr(p); i != i0 && d > 0 && d < minDistance {
+			i1 = i
+			minDistance = d
+		}
+	}
+	if i1 < 0 {
+		return ErrCollinearVertices
+	}
+	// finding the third point which forms the smallest circumcircle with the first two
+	minRadius := math.Inf(1)
+	for i, p := range points {
+		if i == i0 || i == i1 {
+			continue
+		}
+		if r := circumradius(points[i0], points[i1], p); r < minRadius {
+			i2 = i
+			minRadius = r
+		}
+	}
+	if i2 < 0 {
+		return ErrCollinearVertices
+	}
+
+	// the seed triangle must be clockwise
+	if predicates.Orient2D(points[i0], points[i1], points[i2]) > 0 {
+		i1, i2 = i2, i1
+	}
+	t.center = geometry.Circumcenter(points[i0], points[i1], points[i2])
+
+	// sorting the points by distance from the seed triangle circumcenter
+	ids := make([]int, n)
+	distances := make([]float64, n)
+	for i, p := range points {
+		ids[i] = i
+		distances[i] = t.center.DistanceSqr(p)
+	}
+	sort.Slice(ids, func(a, b int) bool {
+		return distances[ids[a]] < distances[ids[b]]
+	})
+
+	// setting up the seed triangle as the starting hull
+	t.hullPrev = make([]int, n)
+	t.hullNext = make([]int, n)
+	t.hullTri = make([]int, n)
+	t.hullHash = make([]int, int(math.Ceil(math.Sqrt(float64(n)))))
+	for i := range t.hullHash {
+		t.hullHash[i] = -1
+	}
+
+	t.hullStart = i0
+	t.hullNext[i0], t.hullPrev[i2] = i1, i1
+	t.hullNext[i1], t.hullPrev[i0] = i2, i2
+	t.hullNext[i2], t.hullPrev[i1] = i0, i0
+	t.hullTri[i0] = 0
+	t.hullTri[i1] = 1
+	t.hullTri[i2] = 2
+	t.hullHash[t.hashKey(points[i0])] = i0
+	t.hullHash[t.hashKey(points[i1])] = i1
+	t.hullHash[t.hashKey(points[i2])] = i2
+
+	t.addTriangle(i0, i1, i2, -1, -1, -1)
+
+	var previous vector.Vector2
+	for k, i := range ids {
+		p := points[i]
+
+		// skipping duplicated points and the seed triangle
+		if k > 0 && p == previous {
+			continue
+		}
+		previous = p
+		if i == i0 || i == i1 || i == i2 {
+			continue
+		}
+
+		// finding a visible edge on the hull using the angle hash
+		start := 0
+		key := t.hashKey(p)
+		for j := range t.hullHash {
+			start = t.hullHash[(key+j)%len(t.hullHash)]
+			if start != -1 && start != t.hullNext[start] {
+				break
+			}
+		}
+		start = t.hullPrev[start]
+		e := start
+		for {
+			q := t.hullNext[e]
+			if predicates.Orient2D(p, points[e], points[q]) > 0 {
+				break
+			}
+			e = q
+			if e == start {
+				e = -1
+				break
+			}
+		}
+		if e < 0 {
+			// the point lies on the hull, most likely a near-duplicate
+			continue
+		}
+
+		// adding the first triangle from the point
+		tri := t.addTriangle(e, i, t.hullNext[e], -1, -1, t.hullTri[e])
+		t.hullTri[i] = t.legalize(tri + 2)
+		t.hullTri[e] = tri
+
+		// walking forward through the hull, adding more triangles
+		next := t.hullNext[e]
+		for {
+			q := t.hullNext[next]
+			if predicates.Orient2D(p, points[next], points[q]) <= 0 {
+				break
+			}
+			tri = t.addTriangle(next, i, q, t.hullTri[i], -1, t.hullTri[next])
+			t.hullTri[i] = t.legalize(tri + 2)
+			// marking as removed
+			t.hullNext[next] = next
+			next = q
+		}
+
+		// walking backward from the other side, adding more triangles
+		if e == start {
+			for {
+				q := t.hullPrev[e]
+				if predicates.Orient2D(p, points[q], points[e]) <= 0 {
+					break
+				}
+				tri = t.addTriangle(q, i, e, -1, t.hullTri[e], t.hullTri[q])
+				t.legalize(tri + 2)
+				t.hullTri[q] = tri
+				// marking as removed
+				t.hullNext[e] = e
+				e = q
+			}
+		}
+
+		// updating the hull
+		t.hullStart = e
+		t.hullPrev[i] = e
+		t.hullNext[e] = i
+		t.hullPrev[next] = i
+		t.hullNext[i] = next
+
+		t.hullHash[t.hashKey(p)] = i
+		t.hullHash[t.hashKey(points[e])] = e
+	}
+	return nil
+}
+
+// hashKey returns the hash of a point according to its angle around the center of the sweep
+func (t *Triangulation) hashKey(p vector.Vector2) int {
+	dx := p.X - t.center.X
+	dy := p.Y - t.center.Y
+
+	// monotonic pseudo-angle in the range [0, 1]
+	angle := dx / (math.Abs(dx) + math.Abs(dy))
+	if dy > 0 {
+		angle = (3 - angle) / 4
+	} else {
+		angle = (1 + angle) / 4
+	}
+
+	return int(math.Floor(angle*float64(len(t.hullHash)))) % len(t.hullHash)
+}
+
+// addTriangle adds a triangle and links its half-edges to the given opposite half-edges.
+// Returns the first half-edge of the triangle.
+func (t *Triangulation) addTriangle(i0, i1, i2, a, b, c int) int {
+	e := len(t.Triangles)
+	t.Triangles = append(t.Triangles, i0, i1, i2)
+	t.HalfEdges = append(t.HalfEdges, -1, -1, -1)
+	t.link(e, a)
+	t.link(e+1, b)
+	t.link(e+2, c)
+
+	t.vertexEdge[i0] = e
+	t.vertexEdge[i1] = e + 1
+	t.vertexEdge[i2] = e + 2
+	return e
+}
+
+// link connects two opposite half-edges
+func (t *Triangulation) link(a, b int) {
+	t.HalfEdges[a] = b
+	if b != -1 {
+		t.HalfEdges[b] = a
+	}
+}
+
+// legalize flips the edge a and the edges around it until they all satisfy the Delaunay condition.
+// Returns the half-edge which replaced the edge preceding a in its triangle.
+func (t *Triangulation) legalize(a int) int {
+	t.edgeStack = t.edgeStack[:0]
+	for {
+		b := t.HalfEdges[a]
+		ar := PrevHalfEdge(a)
+
+		if b == -1 || !t.isIllegal(a) {
+			// the edge is a boundary or already satisfies the Delaunay condition
+			if len(t.edgeStack) == 0 {
+				return ar
+			}
+			a = t.edgeStack[len(t.edgeStack)-1]
+			t.edgeStack = t.edgeStack[:len(t.edgeStack)-1]
+			continue
+		}
+
+		t.flip(a)
+		t.edgeStack = append(t.edgeStack, NextHalfEdge(b))
+	}
+}
+
+// isIllegal determines whether the edge a does not satisfy the Delaunay condition, i.e. the vertex opposite
+// to a in the adjacent triangle lies inside the circumcircle of the triangle of a
+func (t *Triangulation) isIllegal(a int) bool {
+	b := t.HalfEdges[a]
+	if b == -1 {
+		return false
+	}
+
+	p0 := t.Points[t.Triangles[PrevHalfEdge(a)]]
+	pr := t.Points[t.Triangles[a]]
+	pl := t.Points[t.Triangles[NextHalfEdge(a)]]
+	p1 := t.Points[t.Triangles[PrevHalfEdge(b)]]
+
+	return predicates.InCircle(p0, pr, pl, p1) < 0
+}
+
+// flip replaces the edge a, shared by two triangles, by the other diagonal of the quadrilateral they form.
+//
+//	      pl                    pl
+//	     /||\                  /  \
+//	  al/ || \bl            al/    \a
+//	   /  ||  \              /      \
+//	  /  a||b  \    flip    /___ar___\
+//	p0\   ||   /p1   =>   p0\---bl---/p1
+//	   \  ||  /              \      /
+//	  ar\ || /br             b\    /br
+//	     \||/                  \  /
+//	      pr                    pr
+//
+// Returns the half-edge of the new diagonal in the triangle of a, going from p0 to p1.
+func (t *Triangulation) flip(a int) int {
+	b := t.HalfEdges[a]
+	al := NextHalfEdge(a)
+	ar := PrevHalfEdge(a)
+	br := NextHalfEdge(b)
+	bl := PrevHalfEdge(b)
+
+	p0 := t.Triangles[ar]
+	p1 := t.Triangles[bl]
+
+	t.Triangles[a] = p1
+	t.Triangles[b] = p0
+
+	hbl := t.HalfEdges[bl]
+	if hbl == -1 && t.hullTri != nil {
+		// the edge swapped lies on the hull, so its reference must be fixed
+		e := t.hullStart
+		for {
+			if t.hullTri[e] == bl {
+				t.hullTri[e] = a
+				break
+			}
+			if e = t.hullPrev[e]; e == t.hullStart {
+				break
+			}
+		}
+	}
+	t.link(a, hbl)
+	t.link(b, t.HalfEdges[ar])
+	t.link(ar, bl)
+
+	for _, e := range []int{a, al, ar, b, br, bl} {
+		t.vertexEdge[t.Triangles[e]] = e
+	}
+	return ar
+}
+
+// circumradius returns the squared radius of the circumcircle of the triangle abc
+func circumradius(a, b, c vector.Vector2) float64 {
+	return geometry.Circumcenter(a, b, c).DistanceSqr(a)
+}
@@ -0,0 +1,14 @@
+package delaunay
+
+import "errors"
+
+var (
+	ErrNilVertices             = errors.New("The vertex list is nil.")
+	ErrInsufficientVertices    = errors.New("The vertex list must have at least 3 vertices.")
+	ErrCollinearVertices       = errors.New("The vertex list does not contain 3 non-collinear vertices.")
+	ErrNotSimplePolygon        = errors.New("The vertex list does not define a simple polygon.")
+	ErrInvalidConstraint       = errors.New("The constraint does not connect two distinct triangulated vertices.")
+	ErrIntersectingConstraints = errors.New("The constraint intersects another constraint.")
+	ErrInvalidTriangles        = errors.New("The triangle list does not describe a valid triangulation.")
+	ErrPointOutside            = errors.New("The point does not lie inside the triangulation.")
+)
@@ -0,0 +1,423 @@
+package delaunay
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry"
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// minEdgeLength is the length, relative to the size of the triangulation, below which edges are no longer
+// split by the refinement
+const minEdgeLength = 1e-9
+
+// FromTriangles builds a triangulation from an existing set of triangles, such as the ones calculated by the
+// earclipping package. The boundary edges of the triangles become constrained edges.
+//
+// Receives the set of points and the set of indices of the vertices of the triangles, in any winding order.
+// The points are copied, so that inserting new points never modifies the given slice.
+func FromTriangles(points []vector.Vector2, triangles []int) (t *Triangulation, err error) {
+	if points == nil || triangles == nil {
+		err = ErrNilVertices
+		return
+	}
+	if len(triangles)%3 != 0 {
+		err = ErrInvalidTriangles
+		return
+	}
+
+	t = &Triangulation{
+		Points:      append([]vector.Vector2{}, points...),
+		Triangles:   make([]int, 0, len(triangles)),
+		HalfEdges:   make([]int, 0, len(triangles)),
+		vertexEdge:  make([]int, len(points)),
+		constraints: make(map[[2]int]bool),
+	}
+	for i := range t.vertexEdge {
+		t.vertexEdge[i] = -1
+	}
+
+	edges := make(map[[2]int]int, len(triangles))
+	for i := 0; i < len(triangles); i += 3 {
+		a, b, c := triangles[i], triangles[i+1], triangles[i+2]
+		for _, v := range []int{a, b, c} {
+			if v < 0 || v >= len(points) {
+				t = nil
+				err = ErrInvalidTriangles
+				return
+			}
+		}
+
+		o := predicates.Orient2D(points[a], points[b], points[c])
+		if o == 0 {
+			t = nil
+			err = ErrInvalidTriangles
+			return
+		}
+		if o > 0 {
+			b, c = c, b
+		}
+
+		e := t.addTriangle(a, b, c, -1, -1, -1)
+		for k := 0; k < 3; k++ {
+			key := [2]int{t.Triangles[e+k], t.Triangles[NextHalfEdge(e+k)]}
+			if _, ok := edges[key]; ok {
+				// every directed edge must belong to a single triangle
+				t = nil
+				err = ErrInvalidTriangles
+				return
+			}
+			edges[key] = e + k
+			if opposite, ok := edges[[2]int{key[1], key[0]}]; ok {
+				t.link(e+k, opposite)
+			}
+		}
+	}
+
+	for e, opposite := range t.HalfEdges {
+		if opposite == -1 {
+			t.constraints[edgeKey(t.Triangles[e], t.Triangles[NextHalfEdge(e)])] = true
+		}
+	}
+	return
+}
+
+// RestoreDelaunay flips the edges of the triangulation until they all satisfy the Delaunay condition,
+// without ever flipping a constrained edge
+func (t *Triangulation) RestoreDelaunay() {
+	var stack []int
+	for e, opposite := range t.HalfEdges {
+		if opposite > e {
+			stack = append(stack, e)
+		}
+	}
+	t.legalizeEdges(stack)
+}
+
+// InsertPoint adds a point to the triangulation, splitting the triangle or the edge containing it and
+// legalising the edges around it. A constrained edge containing the point is split in two constrained edges.
+//
+// Returns the index of the new vertex.
+func (t *Triangulation) InsertPoint(p vector.Vector2) (int, error) {
+	e, onEdge, blocking := t.locate(p, 0)
+	if blocking != -1 || e == -1 {
+		// the walk may have been stopped by a constrained edge, so every triangle is tried
+		for triangle := 0; triangle < len(t.Triangles)/3; triangle++ {
+			if e, onEdge, blocking = t.locate(p, triangle); blocking == -1 && e != -1 {
+				break
+			}
+		}
+	}
+	if blocking != -1 || e == -1 {
+		return -1, ErrPointOutside
+	}
+
+	return t.insertAt(p, e, onEdge), nil
+}
+
+// Refine inserts Steiner points into the triangulation until no triangle has an angle smaller than the given
+// minimum angle (in radians), following Ruppert's algorithm: the circumcenter of every poor triangle is
+// inserted, unless it encroaches upon a constrained edge, in which case that edge is split instead.
+//
+// Triangles whose smallest angle lies between two constrained edges are left as they are, and edges are never
+// split below a tiny fraction of the size of the triangulation. Termination is only guaranteed for minimum
+// angles up to about 20.7 degrees, so the number of points inserted is also limited.
+// Returns the number of points inserted.
+func (t *Triangulation) Refine(minAngle float64, maxSteinerPoints int) (inserted int) {
+	t.RestoreDelaunay()
+
+	inputVertices := len(t.Points)
+	minLength := t.diameter() * minEdgeLength
+	split := func(e int) bool {
+		if t.edgeLength(e) < minLength {
+			return false
+		}
+		t.splitConstraint(e, inputVertices)
+		inserted++
+		return true
+	}
+
+	for changed := true; changed && inserted < maxSteinerPoints; {
+		changed = false
+
+		// splitting the encroached constrained edges first
+		for e := 0; e < len(t.Triangles) && inserted < maxSteinerPoints; e++ {
+			if t.IsConstrained(e) && t.isEncroached(e, t.Points[t.Triangles[PrevHalfEdge(e)]]) && split(e) {
+				changed = true
+			}
+		}
+
+		for triangle := 0; triangle < len(t.Triangles)/3 && inserted < maxSteinerPoints; triangle++ {
+			if !t.isPoor(triangle, minAngle, minLength) {
+				continue
+			}
+
+			e0 := 3 * triangle
+			center := geometry.Circumcenter(t.Points[t.Triangles[e0]], t.Points[t.Triangles[e0+1]], t.Points[t.Triangles[e0+2]])
+			e, onEdge, blocking := t.locate(center, triangle)
+			switch {
+			case blocking != -1:
+				// the circumcenter lies beyond a constrained edge
+				changed = split(blocking) || changed
+			case e == -1:
+				continue
+			default:
+				if encroached := t.encroachedAround(e, center); encroached != -1 {
+					changed = split(encroached) || changed
+				} else {
+					t.insertAt(center, e, onEdge)
+					inserted++
+					changed = true
+				}
+			}
+		}
+	}
+	return
+}
+
+// isPoor determines whether the triangle has an angle smaller than the minimum angle,
+// which can be improved by inserting points
+func (t *Triangulation) isPoor(triangle int, minAngle, minLength float64) bool {
+	e0 := 3 * triangle
+	smallest := math.Inf(1)
+	vertex := 0
+	for k := 0; k < 3; k++ {
+		e := e0 + k
+		if t.edgeLength(e) < minLength {
+			return false
+		}
+		a := t.Points[t.Triangles[e]]
+		b := t.Points[t.Triangles[NextHalfEdge(e)]]
+		c := t.Points[t.Triangles[PrevHalfEdge(e)]]
+		if angle := geometry.InteriorAngle(a, b, c); angle < smallest {
+			smallest = angle
+			vertex = e
+		}
+	}
+	if smallest >= minAngle {
+		return false
+	}
+
+	// the angle between two constrained edges belongs to the input and can't be improved
+	return !(t.IsConstrained(vertex) && t.IsConstrained(PrevHalfEdge(vertex)))
+}
+
+// isEncroached determines whether the point lies inside the diametral circle of the edge e
+func (t *Triangulation) isEncroached(e int, p vector.Vector2) bool {
+	a := t.Points[t.Triangles[e]]
+	b := t.Points[t.Triangles[NextHalfEdge(e)]]
+	return p != a && p != b && p.To(a).Dot(p.To(b)) < 0
+}
+
+// encroachedAround returns a constrained edge, in the triangle of e or in its neighbours, encroached upon by
+// the point, or -1 if there is none
+func (t *Triangulation) encroachedAround(e int, p vector.Vector2) int {
+	e0 := e - e%3
+	for k := e0; k < e0+3; k++ {
+		if t.IsConstrained(k) && t.isEncroached(k, p) {
+			return k
+		}
+		if opposite := t.HalfEdges[k]; opposite != -1 && !t.IsConstrained(k) {
+			for _, n := range []int{NextHalfEdge(opposite), PrevHalfEdge(opposite)} {
+				if t.IsConstrained(n) && t.isEncroached(n, p) {
+					return n
+				}
+			}
+		}
+	}
+	return -1
+}
+
+// splitConstraint splits the constrained edge e, given the number of vertices which were not inserted by
+// the refinement.
+//
+// An edge with a single input vertex is split on the concentric shells around that vertex, at a power of two
+// distance from it, so that the edges meeting at a small input angle are split at matching lengths instead of
+// encroaching upon each other forever. Any other edge is split at its midpoint.
+func (t *Triangulation) splitConstraint(e, inputVertices int) {
+	a := t.Triangles[e]
+	b := t.Triangles[NextHalfEdge(e)]
+	pa := t.Points[a]
+	pb := t.Points[b]
+
+	s := 0.5
+	if (a < inputVertices) != (b < inputVertices) {
+		length := pa.Distance(pb)
+		s = math.Exp2(math.Round(math.Log2(length/2))) / length
+		if b < inputVertices {
+			s = 1 - s
+		}
+	}
+	t.insertAt(vector.Lerp(pa, pb, s), e, true)
+}
+
+// edgeLength returns the length of the half-edge e
+func (t *Triangulation) edgeLength(e int) float64 {
+	return t.Points[t.Triangles[e]].Distance(t.Points[t.Triangles[NextHalfEdge(e)]])
+}
+
+// diameter returns the length of the diagonal of the bounding box of the points
+func (t *Triangulation) diameter() float64 {
+	if len(t.Points) == 0 {
+		return 0
+	}
+
+	min, max := t.Points[0], t.Points[0]
+	for _, p := range t.Points {
+		min = vector.Vector2{X: math.Min(min.X, p.X), Y: math.Min(min.Y, p.Y)}
+		max = vector.Vector2{X: math.Max(max.X, p.X), Y: math.Max(max.Y, p.Y)}
+	}
+	return min.Distance(max)
+}
+
+// locate walks the triangles from the given one towards the point p, never crossing a constrained edge.
+//
+// Returns a half-edge of the triangle containing p, or the half-edge containing p if it lies on an edge.
+// If the walk is stopped by a constrained edge or the boundary, the blocking half-edge is returned instead.
+// If p coincides with a vertex, the returned half-edge is -1.
+func (t *Triangulation) locate(p vector.Vector2, triangle int) (e int, onEdge bool, blocking int) {
+	blocking = -1
+	// the visibility walk may loop on non-Delaunay triangulations, so the start edge keeps rotating
+	for step := 0; step < len(t.Triangles); step++ {
+		e0 := 3 * triangle
+		moved := false
+		edge := -1
+		for k := 0; k < 3; k++ {
+			e = e0 + (k+step)%3
+			a := t.Points[t.Triangles[e]]
+			b := t.Points[t.Triangles[NextHalfEdge(e)]]
+			if p == a || p == b {
+				return -1, false, -1
+			}
+
+			// the triangle is clockwise, so its interior lies to the right of its edges
+			o := predicates.Orient2D(a, b, p)
+			if o > 0 {
+				if t.HalfEdges[e] == -1 || t.IsConstrained(e) {
+					return -1, false, e
+				}
+				triangle = TriangleOfEdge(t.HalfEdges[e])
+				moved = true
+				break
+			}
+			if o == 0 {
+				edge = e
+			}
+		}
+		if !moved {
+			if edge != -1 {
+				return edge, true, -1
+			}
+			return e0, false, -1
+		}
+	}
+	return -1, false, -1
+}
+
+// insertAt adds the point p to the triangle of the half-edge e, or to the half-edge itself if the point
+// lies on it, and legalises the edges around the new vertex.
+// Returns the index of the new vertex.
+func (t *Triangulation) insertAt(p vector.Vector2, e int, onEdge bool) int {
+	v := len(t.Points)
+	t.Points = append(t.Points, p)
+	t.vertexEdge = append(t.vertexEdge, -1)
+
+	var pending []int
+	if onEdge {
+		pending = t.splitEdge(e, v)
+	} else {
+		pending = t.splitTriangle(e, v)
+	}
+	t.legalizeEdges(pending)
+
+	return v
+}
+
+// splitTriangle connects the vertex v, inside the triangle of the half-edge e, to the vertices of the triangle.
+// Returns the half-edges opposite to v.
+func (t *Triangulation) splitTriangle(e, v int) []int {
+	e0 := e - e%3
+	a, b, c := t.Triangles[e0], t.Triangles[e0+1], t.Triangles[e0+2]
+	h1 := t.HalfEdges[e0+1]
+	h2 := t.HalfEdges[e0+2]
+
+	// the triangle abc becomes abv, bcv and cav
+	t.setTriangle(e0, a, b, v)
+	t1 := t.addTriangle(b, c, v, h1, -1, e0+1)
+	t2 := t.addTriangle(c, a, v, h2, e0+2, t1+1)
+
+	return []int{e0, t1, t2}
+}
+
+// splitEdge connects the vertex v, on the half-edge e, to the vertices opposite to the edge.
+// Returns the half-edges opposite to v.
+func (t *Triangulation) splitEdge(e, v int) []int {
+	opposite := t.HalfEdges[e]
+	x := t.Triangles[e]
+	y := t.Triangles[NextHalfEdge(e)]
+	z := t.Triangles[PrevHalfEdge(e)]
+	hyz := t.HalfEdges[NextHalfEdge(e)]
+
+	if key := edgeKey(x, y); t.constraints[key] {
+		delete(t.constraints, key)
+		t.constraints[edgeKey(x, v)] = true
+		t.constraints[edgeKey(v, y)] = true
+	}
+
+	// the triangle xyz becomes xvz and vyz
+	t.setTriangle(e, x, v, z)
+	a := t.addTriangle(v, y, z, -1, hyz, NextHalfEdge(e))
+	pending := []int{PrevHalfEdge(e), a + 1}
+
+	if opposite == -1 {
+		t.link(e, -1)
+		return pending
+	}
+
+	// the triangle yxw becomes yvw and vxw
+	w := t.Triangles[PrevHalfEdge(opposite)]
+	hxw := t.HalfEdges[NextHalfEdge(opposite)]
+	t.setTriangle(opposite, y, v, w)
+	b := t.addTriangle(v, x, w, e, hxw, NextHalfEdge(opposite))
+	t.link(opposite, a)
+
+	return append(pending, PrevHalfEdge(opposite), b+1)
+}
+
+// setTriangle replaces the vertices of the triangle of the half-edge e, starting at e
+func (t *Triangulation) setTriangle(e, a, b, c int) {
+	t.Triangles[e] = a
+	t.Triangles[NextHalfEdge(e)] = b
+	t.Triangles[PrevHalfEdge(e)] = c
+
+	t.vertexEdge[a] = e
+	t.vertexEdge[b] = NextHalfEdge(e)
+	t.vertexEdge[c] = PrevHalfEdge(e)
+}
+
+// legalizeEdges flips the given edges, and the ones around them, until they all satisfy the Delaunay
+// condition, without ever flipping a constrained edge
+func (t *Triangulation) legalizeEdges(stack []int) {
+	for len(stack) > 0 {
+		a := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		b := t.HalfEdges[a]
+		if b == -1 || t.IsConstrained(a) || !t.isIllegal(a) {
+			continue
+		}
+
+		// the quadrilateral must be strictly convex for the flip to be valid
+		p0 := t.Points[t.Triangles[PrevHalfEdge(a)]]
+		pr := t.Points[t.Triangles[a]]
+		pl := t.Points[t.Triangles[NextHalfEdge(a)]]
+		p1 := t.Points[t.Triangles[PrevHalfEdge(b)]]
+		if !segmentsCross(p0, p1, pr, pl) {
+			continue
+		}
+
+		t.flip(a)
+		stack = append(stack, a, NextHalfEdge(a), b, NextHalfEdge(b))
+	}
+}
@@ -1,15 +1,15 @@
 package earclipping
 
-import (
-	"errors"
-	"fmt"
-)
+import "errors"
 
 var (
 	ErrNilVertices          = errors.New("The vertex list is nil.")
 	ErrInsufficientVertices = errors.New("The vertex list must have at least 3 vertices.")
-	ErrExceededVertices     = fmt.Errorf("The max vertex list length is %d.", maxVertices)
+	ErrExceededVertices     = errors.New("The vertex list exceeds the maximum number of vertices.")
 	ErrNotSimplePolygon     = errors.New("The vertex list does not define a simple polygon.")
 	ErrColinearEdges        = errors.New("The vertex list contains colinear edges.")
 	ErrInvalidWindingOrder  = errors.New("The vertex list does not contain a valid polygon.")
+	ErrHoleOutside          = errors.New("A hole is not contained by the outer polygon.")
+	ErrOverlappingHoles     = errors.New("The holes overlap each other.")
+	ErrNoEarFound           = errors.New("The vertex list could not be fully triangulated.")
 )
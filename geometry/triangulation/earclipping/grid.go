@@ -0,0 +1,121 @@
+package earclipping
+
+import (
+	"math"
+
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// reflexGrid is a uniform grid holding the reflex nodes of a ring.
+// It limits the point-in-triangle tests of the ear search to the nodes close to the tested ear.
+type reflexGrid struct {
+	min         vector.Vector2
+	invCellSize float64
+	columns     int
+	rows        int
+	cells       [][]int
+	cell        []int // cell of each node, or -1 if the node is not in the grid
+}
+
+// gridSide returns the number of cells along each side of the grid covering the given number of vertices.
+// It is a variable so that the benchmarks can compare the grid with a linear scan of the reflex nodes.
+var gridSide = func(vertexCount int) int {
+	return int(math.Ceil(math.Sqrt(float64(vertexCount))))
+}
+
+// newReflexGrid returns an empty grid covering the vertices, with roughly one cell per vertex.
+func newReflexGrid(vertices []vector.Vector2, nodeCount int) *reflexGrid {
+	min := vertices[0]
+	max := vertices[0]
+	for _, v := range vertices {
+		min.X = math.Min(min.X, v.X)
+		min.Y = math.Min(min.Y, v.Y)
+		max.X = math.Max(max.X, v.X)
+		max.Y = math.Max(max.Y, v.Y)
+	}
+
+	size := math.Max(max.X-min.X, max.Y-min.Y)
+	side := gridSide(len(vertices))
+	g := &reflexGrid{
+		min:         min,
+		invCellSize: 0,
+		columns:     1,
+		rows:        1,
+	}
+	if size > 0 {
+		cellSize := size / float64(side)
+		g.invCellSize = 1 / cellSize
+		g.columns = int((max.X-min.X)*g.invCellSize) + 1
+		g.rows = int((max.Y-min.Y)*g.invCellSize) + 1
+	}
+
+	g.cells = make([][]int, g.columns*g.rows)
+	g.cell = make([]int, nodeCount)
+	for i := range g.cell {
+		g.cell[i] = -1
+	}
+	return g
+}
+
+// coordinates returns the column and row of the cell containing the point, clamped to the grid.
+func (g *reflexGrid) coordinates(p vector.Vector2) (column, row int) {
+	column = int((p.X - g.min.X) * g.invCellSize)
+	row = int((p.Y - g.min.Y) * g.invCellSize)
+	if column < 0 {
+		column = 0
+	} else if column >= g.columns {
+		column = g.columns - 1
+	}
+	if row < 0 {
+		row = 0
+	} else if row >= g.rows {
+		row = g.rows - 1
+	}
+	return
+}
+
+// add inserts the node at the given position.
+func (g *reflexGrid) add(node int, p vector.Vector2) {
+	if g.cell[node] >= 0 {
+		return
+	}
+	column, row := g.coordinates(p)
+	cell := row*g.columns + column
+	g.cells[cell] = append(g.cells[cell], node)
+	g.cell[node] = cell
+}
+
+// remove deletes the node from the grid.
+func (g *reflexGrid) remove(node int) {
+	cell := g.cell[node]
+	if cell < 0 {
+		return
+	}
+	nodes := g.cells[cell]
+	for i, n := range nodes {
+		if n == node {
+			nodes[i] = nodes[len(nodes)-1]
+			g.cells[cell] = nodes[:len(nodes)-1]
+			break
+		}
+	}
+	g.cell[node] = -1
+}
+
+// query calls the function for every node in the cells overlapping the box [min, max],
+// stopping as soon as the function returns false.
+// Returns false if the query was stopped.
+func (g *reflexGrid) query(min, max vector.Vector2, f func(node int) bool) bool {
+	minColumn, minRow := g.coordinates(min)
+	maxColumn, maxRow := g.coordinates(max)
+	for row := minRow; row <= maxRow; row++ {
+		for column := minColumn; column <= maxColumn; column++ {
+			for _, node := range g.cells[row*g.columns+column] {
+				if !f(node) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,252 @@
+package earclipping
+
+import (
+	"math"
+	"sort"
+
+	. "github.com/mindera-gaming/go-math/geometry"
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	"github.com/mindera-gaming/go-math/geometry/sweepline"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// TriangulateWithHoles decomposes a simple polygon with holes into a set of triangles.
+//
+// Receives the vertices of the outer polygon, the vertices of each hole and the triangulation options.
+// Each hole is merged into the outer boundary through a pair of bridge edges connecting its rightmost vertex
+// to a visible vertex of the boundary, and the resulting polygon is triangulated.
+// Returns the set of indices of the vertices of the calculated triangles. The indices refer to the
+// concatenation of the outer vertices followed by the vertices of each hole, in the given order.
+func TriangulateWithHoles(outer []vector.Vector2, holes [][]vector.Vector2, options TriangulationOptions) (triangles []int, err error) {
+	if len(holes) == 0 {
+		return Triangulate(outer, options)
+	}
+
+	order, err := validateRing(outer, Clockwise, options)
+	if err != nil {
+		return
+	}
+
+	vertexCount := len(outer)
+	holeOrders := make([]WindingOrder, len(holes))
+	for i, hole := range holes {
+		holeOrders[i], err = validateRing(hole, CounterClockwise, options)
+		if err != nil {
+			return
+		}
+		vertexCount += len(hole)
+	}
+	if exceedsMaxVertices(vertexCount, options) {
+		err = ErrExceededVertices
+		return
+	}
+	if !options.SkipHoleValidation {
+		if err = validateHoles(outer, holes); err != nil {
+			return
+		}
+	}
+
+	// concatenating every ring into a single vertex array
+	vertices := make([]vector.Vector2, 0, vertexCount)
+	vertices = append(vertices, outer...)
+	offsets := make([]int, len(holes))
+	for i, hole := range holes {
+		offsets[i] = len(vertices)
+		vertices = append(vertices, hole...)
+	}
+
+	// the outer boundary must be clockwise, and every hole adds two bridge nodes
+	r := newRing(vertices, vertexCount+2*len(holes))
+	r.insertRange(-1, 0, len(outer), order != Clockwise)
+
+	// merging the holes from right to left, so that a bridge never crosses a hole yet to be merged
+	rightmost := make([]int, len(holes))
+	sorted := make([]int, len(holes))
+	for i, hole := range holes {
+		sorted[i] = i
+		for j := range hole {
+			if hole[j].X > hole[rightmost[i]].X {
+				rightmost[i] = j
+			}
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return holes[sorted[i]][rightmost[sorted[i]]].X > holes[sorted[j]][rightmost[sorted[j]]].X
+	})
+
+	for _, h := range sorted {
+		hole := holes[h]
+		m := vertices[offsets[h]+rightmost[h]]
+
+		bridge := findBridge(r, m)
+		if bridge < 0 {
+			err = ErrHoleOutside
+			return
+		}
+
+		// holes must be counter-clockwise, starting and ending at the rightmost vertex
+		node := bridge
+		for i := 0; i <= len(hole); i++ {
+			j := (rightmost[h] + i) % len(hole)
+			if holeOrders[h] != CounterClockwise {
+				j = (rightmost[h] - i + len(hole)) % len(hole)
+			}
+			node = r.insertAfter(node, offsets[h]+j)
+		}
+		r.insertAfter(node, r.index[bridge])
+	}
+
+	return clipEars(r)
+}
+
+// validateHoles checks whether every hole lies inside the outer polygon and whether the holes overlap each other.
+func validateHoles(outer []vector.Vector2, holes [][]vector.Vector2) error {
+	// the outer polygon is the first ring, so it is always the first of an intersecting pair
+	if first, _ := findIntersectingRings(append([][]vector.Vector2{outer}, holes...)); first == 0 {
+		return ErrHoleOutside
+	} else if first > 0 {
+		return ErrOverlappingHoles
+	}
+
+	for i, hole := range holes {
+		if !ContainsPoint(outer, hole[0], EvenOdd) {
+			return ErrHoleOutside
+		}
+		for j := 0; j < i; j++ {
+			if ContainsPoint(holes[j], hole[0], EvenOdd) || ContainsPoint(hole, holes[j][0], EvenOdd) {
+				return ErrOverlappingHoles
+			}
+		}
+	}
+	return nil
+}
+
+// findIntersectingRings finds two rings whose edges intersect or touch each other, using the sweep-line algorithm.
+// Returns the indices of both rings in ascending order, or -1 if no rings intersect.
+func findIntersectingRings(rings [][]vector.Vector2) (int, int) {
+	var segments []sweepline.Segment
+	var owners []int
+	for r, ring := range rings {
+		for i := range ring {
+			segments = append(segments, sweepline.NewSegment(ring[i], ring[(i+1)%len(ring)]))
+			owners = append(owners, r)
+		}
+	}
+
+	for _, intersection := range sweepline.FindSegmentIntersections(segments, sweepline.Options{}) {
+		first := owners[intersection.Segments[0]]
+		for _, s := range intersection.Segments[1:] {
+			if owners[s] != first {
+				// the segments are sorted, and so are their rings
+				return first, owners[s]
+			}
+		}
+	}
+	return -1, -1
+}
+
+// findBridge finds a boundary node visible from the point m, using the rightmost hole vertex approach.
+//
+// A ray is cast from m towards +X and the closest boundary edge hit provides a candidate vertex.
+// If any reflex vertex lies inside the triangle formed by m, the hit point and the candidate, the one
+// with the smallest angle to the ray is chosen instead.
+// Returns the node of the visible vertex, or -1 if the ray does not hit the boundary.
+func findBridge(r *ring, m vector.Vector2) int {
+	hitX := math.Inf(1)
+	candidate := -1
+
+	// the first node is always part of the outer boundary
+	node := 0
+	for {
+		next := r.next[node]
+		a := r.point(node)
+		b := r.point(next)
+
+		switch {
+		case a.Y == b.Y:
+			if a.Y != m.Y {
+				break
+			}
+			// an edge lying on the ray is hit on its closest endpoint
+			for _, end := range []int{node, next} {
+				x := r.point(end).X
+				if x > m.X && x < hitX {
+					hitX = x
+					candidate = end
+				}
+			}
+		case (a.Y-m.Y)*(b.Y-m.Y) <= 0:
+			x := a.X + (m.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+			if x <= m.X || x >= hitX {
+				break
+			}
+			hitX = x
+			switch {
+			case a.Y == m.Y:
+				candidate = node
+			case b.Y == m.Y:
+				candidate = next
+			case a.X > b.X:
+				candidate = node
+			default:
+				candidate = next
+			}
+		}
+
+		if node = next; node == 0 {
+			break
+		}
+	}
+	if candidate < 0 {
+		return -1
+	}
+
+	hit := vector.Vector2{X: hitX, Y: m.Y}
+	p := r.point(candidate)
+
+	if hit != p {
+		// looking for reflex vertices that may block the visibility of the candidate
+		bestTan := math.Inf(1)
+		bestDistance := math.Inf(1)
+		for node = r.next[0]; ; node = r.next[node] {
+			test := r.point(node)
+			if test != p && r.isReflex(node) && isPointInAnyTriangle(test, m, hit, p) {
+				tan := math.Abs(test.Y-m.Y) / (test.X - m.X)
+				distance := m.DistanceSqr(test)
+				if tan < bestTan || (tan == bestTan && distance < bestDistance) {
+					bestTan = tan
+					bestDistance = distance
+					candidate = node
+				}
+			}
+			if node == 0 {
+				break
+			}
+		}
+		p = r.point(candidate)
+	}
+
+	// the same position may appear several times due to previous bridges,
+	// so the copy whose interior angle faces m is chosen
+	for node = r.next[0]; ; node = r.next[node] {
+		if r.point(node) == p && r.isInSector(node, m) {
+			return node
+		}
+		if node == 0 {
+			break
+		}
+	}
+	return candidate
+}
+
+// isPointInAnyTriangle verifies if the point p is inside the triangle abc, regardless of its winding order.
+func isPointInAnyTriangle(p, a, b, c vector.Vector2) bool {
+	cross1 := predicates.Orient2D(a, b, p)
+	cross2 := predicates.Orient2D(b, c, p)
+	cross3 := predicates.Orient2D(c, a, p)
+
+	hasNegative := cross1 < 0 || cross2 < 0 || cross3 < 0
+	hasPositive := cross1 > 0 || cross2 > 0 || cross3 > 0
+
+	return !(hasNegative && hasPositive)
+}
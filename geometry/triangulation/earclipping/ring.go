@@ -0,0 +1,92 @@
+package earclipping
+
+import (
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// ring is a circular doubly linked list of polygon vertices, stored in flat slices.
+//
+// Each node references a vertex index, and the same index may be referenced by several nodes
+// (e.g. the bridge edges used to merge holes).
+type ring struct {
+	vertices []vector.Vector2
+	index    []int // vertex index of each node
+	prev     []int // previous node of each node
+	next     []int // next node of each node
+}
+
+// newRing returns an empty ring over the given vertices.
+func newRing(vertices []vector.Vector2, capacity int) *ring {
+	return &ring{
+		vertices: vertices,
+		index:    make([]int, 0, capacity),
+		prev:     make([]int, 0, capacity),
+		next:     make([]int, 0, capacity),
+	}
+}
+
+// insertAfter creates a node referencing the vertex index and links it after the given node.
+// A negative node creates a new closed ring.
+// Returns the created node.
+func (r *ring) insertAfter(node, vertexIndex int) int {
+	n := len(r.index)
+	r.index = append(r.index, vertexIndex)
+	if node < 0 {
+		r.prev = append(r.prev, n)
+		r.next = append(r.next, n)
+		return n
+	}
+
+	r.prev = append(r.prev, node)
+	r.next = append(r.next, r.next[node])
+	r.prev[r.next[node]] = n
+	r.next[node] = n
+	return n
+}
+
+// insertRange links the vertex indices [offset, offset+length) after the given node, in reverse order if requested.
+// Returns the last created node.
+func (r *ring) insertRange(node, offset, length int, reverse bool) int {
+	for i := 0; i < length; i++ {
+		if reverse {
+			node = r.insertAfter(node, offset+length-1-i)
+		} else {
+			node = r.insertAfter(node, offset+i)
+		}
+	}
+	return node
+}
+
+// remove unlinks the node from the ring.
+func (r *ring) remove(node int) {
+	r.next[r.prev[node]] = r.next[node]
+	r.prev[r.next[node]] = r.prev[node]
+}
+
+// point returns the position of the node.
+func (r *ring) point(node int) vector.Vector2 {
+	return r.vertices[r.index[node]]
+}
+
+// isReflex determines whether the node is a reflex (or degenerate) vertex of a clockwise polygon.
+func (r *ring) isReflex(node int) bool {
+	current := r.point(node)
+	return predicates.Orient2D(current, r.point(r.prev[node]), r.point(r.next[node])) <= 0
+}
+
+// isInSector determines whether the point p lies within the interior angle of the node of a clockwise polygon.
+func (r *ring) isInSector(node int, p vector.Vector2) bool {
+	current := r.point(node)
+	previous := r.point(r.prev[node])
+	next := r.point(r.next[node])
+
+	// the interior of a clockwise polygon lies to the right of its edges
+	rightOfIncoming := predicates.Orient2D(previous, current, p) < 0
+	rightOfOutgoing := predicates.Orient2D(current, next, p) < 0
+
+	if predicates.Orient2D(current, previous, next) > 0 {
+		return rightOfIncoming && rightOfOutgoing
+	}
+	return rightOfIncoming || rightOfOutgoing
+}
@@ -1,24 +1,19 @@
 package earclipping
 
 import (
-	"container/list"
+	"math"
 
-	listHelper "github.com/mindera-gaming/go-data-structure/list"
 	. "github.com/mindera-gaming/go-math/geometry"
 	vector "github.com/mindera-gaming/go-math/vector2"
 )
 
-const (
-	// No specific reason for choosing this value.
-	// Just to limit the maximum number of vertices.
-	maxVertices = 1000
-)
-
 // TriangulationOptions is the structure that defines the triangulation options.
 type TriangulationOptions struct {
 	SkipSimplePolygonValidation bool // set to true to skip the simple polygon verification
 	SkipColinearEdgesValidation bool // set to true to skip the collinear edge verification
 	SkipWindingOrderValidation  bool // set to true to skip the winding order verification
+	SkipHoleValidation          bool // set to true to skip the hole containment and overlap verification
+	MaxVertices                 int  // maximum number of vertices accepted, zero means no limit
 }
 
 // Triangulate decomposes a simple polygon into a set of triangles.
@@ -26,16 +21,77 @@ type TriangulationOptions struct {
 // Receives the set of vertices of a polygon and the triagulation options.
 // Returns the set of indices of the vertices of the calculated triangles.
 func Triangulate(vertices []vector.Vector2, options TriangulationOptions) (triangles []int, err error) {
-	if vertices == nil {
+	if exceedsMaxVertices(len(vertices), options) {
+		err = ErrExceededVertices
+		return
+	}
+	order, err := validateRing(vertices, Clockwise, options)
+	if err != nil {
+		return
+	}
+
+	r := newRing(vertices, len(vertices))
+	r.insertRange(-1, 0, len(vertices), order != Clockwise)
+
+	return clipEars(r)
+}
+
+// TriangulatePolygon decomposes a simple polygon into a set of triangles.
+//
+// Receives a polygon and the triagulation options. The polygon is validated through its cached properties.
+// Returns the set of indices of the vertices of the calculated triangles.
+func TriangulatePolygon(polygon *Polygon, options TriangulationOptions) (triangles []int, err error) {
+	if polygon == nil {
 		err = ErrNilVertices
 		return
 	}
-	if len(vertices) < 3 {
+	if exceedsMaxVertices(polygon.Len(), options) {
+		err = ErrExceededVertices
+		return
+	}
+	if polygon.Len() < 3 {
 		err = ErrInsufficientVertices
 		return
 	}
-	if len(vertices) > maxVertices {
-		err = ErrExceededVertices
+	if !options.SkipSimplePolygonValidation && !polygon.IsSimple() {
+		err = ErrNotSimplePolygon
+		return
+	}
+	if !options.SkipColinearEdgesValidation && polygon.ContainsColinearEdges() {
+		err = ErrColinearEdges
+		return
+	}
+
+	order := Clockwise
+	if !options.SkipWindingOrderValidation {
+		if order = polygon.WindingOrder(); order == Invalid {
+			err = ErrInvalidWindingOrder
+			return
+		}
+	}
+
+	r := newRing(polygon.Vertices(), polygon.Len())
+	r.insertRange(-1, 0, polygon.Len(), order != Clockwise)
+
+	return clipEars(r)
+}
+
+// exceedsMaxVertices determines whether the vertex count is over the limit set in the options.
+func exceedsMaxVertices(count int, options TriangulationOptions) bool {
+	return options.MaxVertices > 0 && count > options.MaxVertices
+}
+
+// validateRing checks whether a ring of vertices can be triangulated.
+//
+// Receives the ring, the winding order assumed when the validation is skipped and the triangulation options.
+// Returns the winding order of the ring.
+func validateRing(vertices []vector.Vector2, assumed WindingOrder, options TriangulationOptions) (order WindingOrder, err error) {
+	if vertices == nil {
+		err = ErrNilVertices
+		return
+	}
+	if len(vertices) < 3 {
+		err = ErrInsufficientVertices
 		return
 	}
 	if !options.SkipSimplePolygonValidation {
@@ -51,91 +107,132 @@ func Triangulate(vertices []vector.Vector2, options TriangulationOptions) (trian
 		}
 	}
 
-	var order WindingOrder = Clockwise
+	order = assumed
 	if !options.SkipWindingOrderValidation {
 		_, order = ComputePolygonArea(vertices)
 		if order == Invalid {
 			err = ErrInvalidWindingOrder
 			return
 		}
-		if order == CounterClockwise {
-			vector.ReverseSlice(&vertices)
-		}
 	}
+	return
+}
 
-	indexList := list.New()
-	for i := 0; i < len(vertices); i++ {
-		indexList.PushBack(i)
+// clipEars triangulates the clockwise polygon described by the ring.
+//
+// The reflex vertices are kept in a uniform grid, so an ear candidate is only tested against the reflex
+// vertices close to it, and the candidates are kept in a queue which is fed with the neighbours of every
+// ear clipped. Only when the queue runs dry is the whole polygon searched again.
+// Returns the set of indices of the vertices of the calculated triangles.
+func clipEars(r *ring) (triangles []int, err error) {
+	nodeCount := len(r.index)
+	triangles = make([]int, 0, (nodeCount-2)*3)
+
+	reflex := make([]bool, nodeCount)
+	grid := newReflexGrid(r.vertices, nodeCount)
+	for node := 0; node < nodeCount; node++ {
+		if r.isReflex(node) {
+			reflex[node] = true
+			grid.add(node, r.point(node))
+		}
 	}
 
-	totalTriangleCount := len(vertices) - 2
-	totalTriangleIndexCount := totalTriangleCount * 3
-
-	triangles = make([]int, totalTriangleIndexCount)
-	triangleIndexCount := 0
-
-	for indexList.Len() > 3 {
-		for testIndex := indexList.Front(); testIndex != nil; testIndex = testIndex.Next() {
-			current := testIndex.Value.(int)
-			previous := listHelper.PreviousCircularElement(indexList, testIndex).Value.(int)
-			next := listHelper.NextCircularElement(indexList, testIndex).Value.(int)
+	removed := make([]bool, nodeCount)
+	queued := make([]bool, nodeCount)
+	queue := make([]int, 0, nodeCount)
+	enqueue := func(node int) {
+		if !queued[node] && !removed[node] {
+			queued[node] = true
+			queue = append(queue, node)
+		}
+	}
 
-			currentVector := vertices[current]
-			previousVector := vertices[previous]
-			nextVector := vertices[next]
+	remaining := nodeCount
+	current := 0
+	progress := true
+	for remaining > 3 {
+		if len(queue) == 0 {
+			if !progress {
+				triangles = nil
+				err = ErrNoEarFound
+				return
+			}
+			// searching the whole polygon again
+			progress = false
+			node := current
+			for i := 0; i < remaining; i++ {
+				enqueue(node)
+				node = r.next[node]
+			}
+		}
 
-			currentToPreviousVector := currentVector.To(previousVector)
-			currentToNextVector := currentVector.To(nextVector)
+		node := queue[0]
+		queue = queue[1:]
+		queued[node] = false
+		if removed[node] || reflex[node] || !isEar(r, grid, node) {
+			continue
+		}
 
-			// checks if the test vertex is convex (valid)
-			if currentToPreviousVector.Cross(currentToNextVector) <= 0 {
-				// test vertex is reflex (not valid)
-				continue
+		previous := r.prev[node]
+		next := r.next[node]
+		triangles = append(triangles, r.index[previous], r.index[node], r.index[next])
+
+		// remove the ear found
+		r.remove(node)
+		removed[node] = true
+		remaining--
+		current = next
+		progress = true
+
+		// the neighbours are the only vertices whose angle changed
+		for _, neighbour := range []int{previous, next} {
+			isReflex := r.isReflex(neighbour)
+			if isReflex != reflex[neighbour] {
+				reflex[neighbour] = isReflex
+				if isReflex {
+					grid.add(neighbour, r.point(neighbour))
+				} else {
+					grid.remove(neighbour)
+				}
 			}
+			enqueue(neighbour)
+		}
+	}
 
-			isEar := true
-
-			// checks if the test ear contains any polygon vertices
-			for testEarIndex := indexList.Front(); testEarIndex != nil; testEarIndex = testEarIndex.Next() {
-				earIndexValue := testEarIndex.Value.(int)
+	triangles = append(triangles, r.index[r.prev[current]], r.index[current], r.index[r.next[current]])
 
-				if earIndexValue == current || earIndexValue == previous || earIndexValue == next {
-					continue
-				}
+	return
+}
 
-				p := vertices[earIndexValue]
-				if IsPointInTriangle(p, previousVector, currentVector, nextVector) {
-					isEar = false
-					break
-				}
-			}
+// isEar determines whether the convex node is an ear, i.e. no reflex vertex lies inside its triangle.
+func isEar(r *ring, grid *reflexGrid, node int) bool {
+	previous := r.prev[node]
+	next := r.next[node]
 
-			if isEar {
-				triangles[triangleIndexCount] = previous
-				triangles[triangleIndexCount+1] = current
-				triangles[triangleIndexCount+2] = next
-				triangleIndexCount += 3
+	previousVector := r.point(previous)
+	currentVector := r.point(node)
+	nextVector := r.point(next)
 
-				// remove the ear found
-				indexList.Remove(testIndex)
-				break
-			}
-		}
+	min := vector.Vector2{
+		X: math.Min(previousVector.X, math.Min(currentVector.X, nextVector.X)),
+		Y: math.Min(previousVector.Y, math.Min(currentVector.Y, nextVector.Y)),
+	}
+	max := vector.Vector2{
+		X: math.Max(previousVector.X, math.Max(currentVector.X, nextVector.X)),
+		Y: math.Max(previousVector.Y, math.Max(currentVector.Y, nextVector.Y)),
 	}
 
-	index := indexList.Front()
-	triangles[triangleIndexCount] = index.Value.(int)
-	triangles[triangleIndexCount+1] = index.Next().Value.(int)
-	triangles[triangleIndexCount+2] = index.Next().Next().Value.(int)
-
-	// in case the polygon has been sent counter-clockwise winding order
-	if order == CounterClockwise {
-		// reverses the indices of the triangles
-		lenIndex := len(vertices) - 1
-		for i := 0; i < len(triangles); i++ {
-			triangles[i] = lenIndex - triangles[i]
+	// checks if the test ear contains any reflex vertex
+	return grid.query(min, max, func(test int) bool {
+		if test == previous || test == node || test == next {
+			return true
 		}
-	}
 
-	return
+		p := r.point(test)
+		// duplicated positions belong to bridge edges and can't be inside the ear
+		if p == previousVector || p == currentVector || p == nextVector {
+			return true
+		}
+		return !IsPointInTriangle(p, previousVector, currentVector, nextVector)
+	})
 }
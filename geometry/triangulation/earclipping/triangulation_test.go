@@ -0,0 +1,162 @@
+package earclipping
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mindera-gaming/go-math/geometry"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// square returns the counter-clockwise square between the corners (minX, minY) and (maxX, maxY)
+func square(minX, minY, maxX, maxY float64) []vector.Vector2 {
+	return []vector.Vector2{{X: minX, Y: minY}, {X: maxX, Y: minY}, {X: maxX, Y: maxY}, {X: minX, Y: maxY}}
+}
+
+// reversed returns the ring in the opposite order
+func reversed(ring []vector.Vector2) []vector.Vector2 {
+	result := make([]vector.Vector2, len(ring))
+	for i, v := range ring {
+		result[len(ring)-1-i] = v
+	}
+	return result
+}
+
+func TestTriangulateWithHoles(t *testing.T) {
+	outer := reversed(square(0, 0, 10, 10))
+	tests := []struct {
+		name  string
+		holes [][]vector.Vector2
+		err   error
+	}{
+		{"single hole", [][]vector.Vector2{square(3, 3, 6, 6)}, nil},
+		{"clockwise hole", [][]vector.Vector2{reversed(square(3, 3, 6, 6))}, nil},
+		{"two holes", [][]vector.Vector2{square(1, 1, 4, 4), square(6, 2, 8, 9)}, nil},
+		{"hole outside", [][]vector.Vector2{square(20, 20, 23, 23)}, ErrHoleOutside},
+		{"hole crossing", [][]vector.Vector2{square(8, 3, 12, 6)}, ErrHoleOutside},
+		{"hole touching", [][]vector.Vector2{{{X: 3, Y: 3}, {X: 10, Y: 5}, {X: 3, Y: 6}}}, ErrHoleOutside},
+		{"overlapping holes", [][]vector.Vector2{square(2, 2, 5, 5), square(4, 4, 7, 7)}, ErrOverlappingHoles},
+		{"nested holes", [][]vector.Vector2{square(2, 2, 8, 8), square(4, 4, 5, 5)}, ErrOverlappingHoles},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			triangles, err := TriangulateWithHoles(outer, test.holes, TriangulationOptions{})
+			if err != test.err {
+				t.Fatalf("got the error %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+
+			// every hole adds two bridge edges, so two triangles more than its vertices
+			vertices := append([]vector.Vector2{}, outer...)
+			area, _ := geometry.ComputePolygonArea(outer)
+			for _, hole := range test.holes {
+				vertices = append(vertices, hole...)
+				holeArea, _ := geometry.ComputePolygonArea(hole)
+				area -= holeArea
+			}
+			if want := len(vertices) + 2*len(test.holes) - 2; len(triangles) != 3*want {
+				t.Fatalf("got %d triangles, want %d", len(triangles)/3, want)
+			}
+
+			var total float64
+			for i := 0; i < len(triangles); i += 3 {
+				triangle := []vector.Vector2{vertices[triangles[i]], vertices[triangles[i+1]], vertices[triangles[i+2]]}
+				triangleArea, order := geometry.ComputePolygonArea(triangle)
+				if order != geometry.Clockwise {
+					t.Fatalf("triangle %v is not clockwise", triangles[i:i+3])
+				}
+				total += triangleArea
+			}
+			if math.Abs(total-area) > 1e-9 {
+				t.Fatalf("the triangles cover an area of %v, want %v", total, area)
+			}
+		})
+	}
+}
+
+// spikyRing returns a clockwise star-shaped ring around the origin, whose random radii between 50 and 90 make
+// about half of its vertices reflex
+func spikyRing(r *rand.Rand, n int) []vector.Vector2 {
+	vertices := make([]vector.Vector2, n)
+	for i := range vertices {
+		angle := -2 * math.Pi * float64(i) / float64(n)
+		radius := 50 + 40*r.Float64()
+		vertices[i] = vector.Vector2{X: radius * math.Cos(angle), Y: radius * math.Sin(angle)}
+	}
+	return vertices
+}
+
+// octagonHoles returns counter-clockwise octagons laid on a square grid inside the disc of radius 50
+func octagonHoles(count int) [][]vector.Vector2 {
+	side := int(math.Ceil(math.Sqrt(float64(count))))
+	spacing := 60 / float64(side)
+	holes := make([][]vector.Vector2, 0, count)
+	for i := 0; len(holes) < count; i++ {
+		centre := vector.Vector2{
+			X: -30 + spacing*(float64(i%side)+0.5),
+			Y: -30 + spacing*(float64(i/side)+0.5),
+		}
+		hole := make([]vector.Vector2, 8)
+		for j := range hole {
+			angle := 2 * math.Pi * float64(j) / float64(len(hole))
+			hole[j] = centre.Add(vector.Vector2{X: math.Cos(angle), Y: math.Sin(angle)}.Mul(spacing / 4))
+		}
+		holes = append(holes, hole)
+	}
+	return holes
+}
+
+// benchmarkTriangulation triangulates rings of about 1k, 10k and 100k vertices, with one hole every hundred
+// vertices if asked to. The reflex-vertex grid is compared with a grid of a single cell, where every ear candidate
+// is tested against every reflex vertex, on the rings small enough for the latter to finish
+func benchmarkTriangulation(b *testing.B, withHoles bool) {
+	options := TriangulationOptions{
+		SkipSimplePolygonValidation: true,
+		SkipColinearEdgesValidation: true,
+		SkipHoleValidation:          true,
+	}
+	defer func(side func(int) int) {
+		gridSide = side
+	}(gridSide)
+	grid := gridSide
+	linear := func(int) int {
+		return 1
+	}
+
+	for _, n := range []int{1000, 10000, 100000} {
+		outer := spikyRing(rand.New(rand.NewSource(1)), n)
+		var holes [][]vector.Vector2
+		if withHoles {
+			holes = octagonHoles(n / 100)
+		}
+
+		for _, search := range []struct {
+			name string
+			side func(int) int
+		}{{"grid", grid}, {"linear", linear}} {
+			if search.name == "linear" && n > 10000 {
+				continue
+			}
+			b.Run(fmt.Sprintf("%s/%d", search.name, n), func(b *testing.B) {
+				gridSide = search.side
+				for i := 0; i < b.N; i++ {
+					if _, err := TriangulateWithHoles(outer, holes, options); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkTriangulate(b *testing.B) {
+	benchmarkTriangulation(b, false)
+}
+
+func BenchmarkTriangulateWithHoles(b *testing.B) {
+	benchmarkTriangulation(b, true)
+}
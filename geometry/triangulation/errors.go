@@ -0,0 +1,10 @@
+package triangulation
+
+import "errors"
+
+var (
+	ErrNilVertices          = errors.New("The vertex list is nil.")
+	ErrInvalidIndexCount    = errors.New("The index list length must be a multiple of 3.")
+	ErrIndexOutOfRange      = errors.New("The index list references a vertex outside the vertex list.")
+	ErrInvalidTriangulation = errors.New("The index list contains degenerate or overlapping triangles.")
+)
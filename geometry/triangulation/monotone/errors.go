@@ -0,0 +1,10 @@
+package monotone
+
+import "errors"
+
+var (
+	ErrNilVertices          = errors.New("The vertex list is nil.")
+	ErrInsufficientVertices = errors.New("The vertex list must have at least 3 vertices.")
+	ErrNotSimplePolygon     = errors.New("The vertex list does not define a simple polygon.")
+	ErrInvalidWindingOrder  = errors.New("The vertex list does not contain a valid polygon.")
+)
@@ -0,0 +1,168 @@
+// Package monotone provides a polygon triangulation solution by partitioning it into monotone pieces
+package monotone
+
+import (
+	. "github.com/mindera-gaming/go-math/geometry"
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// TriangulationOptions is the structure that defines the triangulation options.
+type TriangulationOptions struct {
+	SkipSimplePolygonValidation bool // set to true to skip the simple polygon verification
+	SkipWindingOrderValidation  bool // set to true to skip the winding order verification
+}
+
+// Triangulate decomposes a simple polygon into a set of triangles.
+//
+// The polygon is partitioned into y-monotone pieces with a sweep-line, and each piece is then triangulated
+// in linear time, resulting in an O(n log n) triangulation.
+//
+// Receives the set of vertices of a polygon and the triagulation options.
+// Returns the set of indices of the vertices of the calculated triangles, in clockwise order.
+func Triangulate(vertices []vector.Vector2, options TriangulationOptions) (triangles []int, err error) {
+	ring, err := validate(vertices, options)
+	if err != nil {
+		return
+	}
+
+	pieces, err := partition(vertices, ring)
+	if err != nil {
+		return
+	}
+
+	triangles = make([]int, 0, (len(vertices)-2)*3)
+	for _, piece := range pieces {
+		triangles = triangulateMonotone(vertices, piece, triangles)
+	}
+	return
+}
+
+// validate checks whether the polygon can be triangulated.
+// Returns the indices of its vertices in counter-clockwise order.
+func validate(vertices []vector.Vector2, options TriangulationOptions) (ring []int, err error) {
+	if vertices == nil {
+		err = ErrNilVertices
+		return
+	}
+	if len(vertices) < 3 {
+		err = ErrInsufficientVertices
+		return
+	}
+	if !options.SkipSimplePolygonValidation {
+		if !IsSimplePolygon(vertices) {
+			err = ErrNotSimplePolygon
+			return
+		}
+	}
+
+	var order WindingOrder = Clockwise
+	if !options.SkipWindingOrderValidation {
+		_, order = ComputePolygonArea(vertices)
+		if order == Invalid {
+			err = ErrInvalidWindingOrder
+			return
+		}
+	}
+
+	ring = make([]int, len(vertices))
+	for i := range ring {
+		ring[i] = i
+	}
+	if order == Clockwise {
+		reverse(ring)
+	}
+	return
+}
+
+// triangulateMonotone triangulates a y-monotone piece in linear time, using a stack of the vertices
+// which still need triangles.
+//
+// Receives the counter-clockwise indices of the vertices of the piece.
+// Returns the triangles slice with the clockwise triangles of the piece appended.
+func triangulateMonotone(vertices []vector.Vector2, piece []int, triangles []int) []int {
+	n := len(piece)
+	if n == 3 {
+		return appendTriangle(vertices, triangles, piece[0], piece[1], piece[2])
+	}
+
+	top := 0
+	bottom := 0
+	for i := range piece {
+		if above(vertices[piece[i]], vertices[piece[top]]) {
+			top = i
+		}
+		if above(vertices[piece[bottom]], vertices[piece[i]]) {
+			bottom = i
+		}
+	}
+
+	// merging both chains from top to bottom: going forward from the top follows the left chain
+	// and going backward follows the right chain
+	sorted := make([]int, 0, n)
+	onLeft := make(map[int]bool, n)
+	left := top
+	right := (top - 1 + n) % n
+	for len(sorted) < n {
+		if left != bottom && (right == bottom || above(vertices[piece[left]], vertices[piece[right]])) {
+			sorted = append(sorted, piece[left])
+			onLeft[piece[left]] = true
+			left = (left + 1) % n
+		} else {
+			sorted = append(sorted, piece[right])
+			right = (right - 1 + n) % n
+		}
+		if left == bottom && right == bottom {
+			sorted = append(sorted, piece[bottom])
+		}
+	}
+
+	stack := []int{sorted[0], sorted[1]}
+	for j := 2; j < n-1; j++ {
+		u := sorted[j]
+		last := stack[len(stack)-1]
+
+		if onLeft[u] != onLeft[last] {
+			// the vertex sees every vertex in the stack
+			for k := len(stack) - 1; k > 0; k-- {
+				triangles = appendTriangle(vertices, triangles, u, stack[k], stack[k-1])
+			}
+			stack = append(stack[:0], last, u)
+			continue
+		}
+
+		// the vertex sees the vertices in the stack as long as the chain turns towards the interior
+		stack = stack[:len(stack)-1]
+		for len(stack) > 0 {
+			next := stack[len(stack)-1]
+			var turn float64
+			if onLeft[u] {
+				turn = predicates.Orient2D(vertices[next], vertices[last], vertices[u])
+			} else {
+				turn = predicates.Orient2D(vertices[u], vertices[last], vertices[next])
+			}
+			if turn <= 0 {
+				break
+			}
+			triangles = appendTriangle(vertices, triangles, u, last, next)
+			last = next
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, last, u)
+	}
+
+	// the bottom vertex sees every vertex in the stack
+	u := sorted[n-1]
+	for k := len(stack) - 1; k > 0; k-- {
+		triangles = appendTriangle(vertices, triangles, u, stack[k], stack[k-1])
+	}
+	return triangles
+}
+
+// appendTriangle appends the triangle abc to the triangles slice, in clockwise order
+func appendTriangle(vertices []vector.Vector2, triangles []int, a, b, c int) []int {
+	if predicates.Orient2D(vertices[a], vertices[b], vertices[c]) > 0 {
+		b, c = c, b
+	}
+	return append(triangles, a, b, c)
+}
@@ -0,0 +1,157 @@
+package monotone
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mindera-gaming/go-math/geometry"
+	"github.com/mindera-gaming/go-math/geometry/triangulation/earclipping"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// randomStar returns a star-shaped polygon around the origin, whose vertices have random radii
+func randomStar(r *rand.Rand, n int, clockwise bool) []vector.Vector2 {
+	vertices := make([]vector.Vector2, n)
+	for i := range vertices {
+		angle := 2 * math.Pi * (float64(i) + 0.8*r.Float64()) / float64(n)
+		if clockwise {
+			angle = -angle
+		}
+		radius := 10 + 90*r.Float64()
+		vertices[i] = vector.Vector2{X: radius * math.Cos(angle), Y: radius * math.Sin(angle)}
+	}
+	return vertices
+}
+
+// comb returns a clockwise polygon with teeth pointing up and down, which holds many split and merge vertices
+func comb(teeth int) []vector.Vector2 {
+	var vertices []vector.Vector2
+	for i := 0; i < teeth; i++ {
+		x := float64(2 * i)
+		vertices = append(vertices, vector.Vector2{X: x, Y: 1}, vector.Vector2{X: x + 0.5, Y: 4 + 0.1*float64(i%3)},
+			vector.Vector2{X: x + 1, Y: 1.5})
+	}
+	for i := teeth - 1; i >= 0; i-- {
+		x := float64(2 * i)
+		vertices = append(vertices, vector.Vector2{X: x + 1, Y: -1.5}, vector.Vector2{X: x + 0.5, Y: -4 - 0.1*float64(i%2)},
+			vector.Vector2{X: x, Y: -1})
+	}
+	return vertices
+}
+
+// triangulationArea returns the total area of the triangles, failing if any of them is not clockwise
+func triangulationArea(t *testing.T, vertices []vector.Vector2, triangles []int) float64 {
+	t.Helper()
+	if len(triangles)%3 != 0 {
+		t.Fatalf("%d indices do not describe triangles", len(triangles))
+	}
+	var total float64
+	for i := 0; i < len(triangles); i += 3 {
+		triangle := []vector.Vector2{vertices[triangles[i]], vertices[triangles[i+1]], vertices[triangles[i+2]]}
+		area, order := geometry.ComputePolygonArea(triangle)
+		if order != geometry.Clockwise {
+			t.Fatalf("triangle %v is not clockwise", triangles[i:i+3])
+		}
+		total += area
+	}
+	return total
+}
+
+// compareWithEarClipping checks that both triangulations of the polygon cover its area with the same number
+// of triangles
+func compareWithEarClipping(t *testing.T, vertices []vector.Vector2) {
+	t.Helper()
+	triangles, err := Triangulate(vertices, TriangulationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := earclipping.Triangulate(vertices, earclipping.TriangulationOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(triangles) != len(expected) || len(triangles) != 3*(len(vertices)-2) {
+		t.Fatalf("got %d triangles, ear clipping got %d, for %d vertices",
+			len(triangles)/3, len(expected)/3, len(vertices))
+	}
+	area, _ := geometry.ComputePolygonArea(vertices)
+	got := triangulationArea(t, vertices, triangles)
+	want := triangulationArea(t, vertices, expected)
+	if math.Abs(got-want) > 1e-9*area || math.Abs(got-area) > 1e-9*area {
+		t.Fatalf("got an area of %v, ear clipping got %v, for a polygon of area %v", got, want, area)
+	}
+}
+
+func TestTriangulateStars(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for iteration := 0; iteration < 500; iteration++ {
+		vertices := randomStar(r, 3+r.Intn(100), iteration%2 == 0)
+		compareWithEarClipping(t, vertices)
+	}
+}
+
+func TestTriangulateComb(t *testing.T) {
+	for _, teeth := range []int{1, 2, 5, 50} {
+		t.Run(fmt.Sprint(teeth), func(t *testing.T) {
+			compareWithEarClipping(t, comb(teeth))
+		})
+	}
+}
+
+func TestPartitionIsMonotone(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for iteration := 0; iteration < 200; iteration++ {
+		vertices := randomStar(r, 3+r.Intn(60), false)
+		pieces, err := Partition(vertices, TriangulationOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var total float64
+		for _, piece := range pieces {
+			points := make([]vector.Vector2, len(piece))
+			for i, index := range piece {
+				points[i] = vertices[index]
+			}
+			area, order := geometry.ComputePolygonArea(points)
+			if order != geometry.Clockwise {
+				t.Fatalf("piece %v is not clockwise", piece)
+			}
+			total += area
+
+			// a y-monotone piece has a single top and a single bottom vertex
+			turns := 0
+			for i := range points {
+				previous := points[(i-1+len(points))%len(points)]
+				next := points[(i+1)%len(points)]
+				if above(points[i], previous) == above(points[i], next) {
+					turns++
+				}
+			}
+			if turns != 2 {
+				t.Fatalf("piece %v is not y-monotone", points)
+			}
+		}
+
+		area, _ := geometry.ComputePolygonArea(vertices)
+		if math.Abs(total-area) > 1e-9*area {
+			t.Fatalf("the pieces cover an area of %v, for a polygon of area %v", total, area)
+		}
+	}
+}
+
+func BenchmarkTriangulate(b *testing.B) {
+	for _, n := range []int{1000, 10000, 100000} {
+		vertices := randomStar(rand.New(rand.NewSource(3)), n, true)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			options := TriangulationOptions{SkipSimplePolygonValidation: true}
+			for i := 0; i < b.N; i++ {
+				if _, err := Triangulate(vertices, options); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
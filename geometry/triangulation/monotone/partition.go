@@ -0,0 +1,304 @@
+package monotone
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	"github.com/mindera-gaming/go-math/geometry/sweepline"
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Partitioning a polygon into y-monotone pieces using a sweep-line, based on the algorithm found here:
+// Book: Computational Geometry: Algorithms and Applications, 3rd edition, chapter 3.2
+
+// vertexType defines an enumerator that represents the type of a vertex during the sweep
+type vertexType byte
+
+const (
+	regular vertexType = iota
+	start
+	end
+	split
+	merge
+)
+
+// status defines the sweep-line status: the edges crossed by the sweep-line which have the interior of the
+// polygon to their right, sorted from left to right, along with their helper vertices
+type status struct {
+	vertices []vector.Vector2
+	ring     []int // counter-clockwise vertex indices of the polygon
+	sweep    vector.Vector2
+	edges    sweepline.Status
+	helper   []int // helper ring position of each edge in the status
+}
+
+// Partition decomposes a simple polygon into y-monotone pieces.
+//
+// Receives the set of vertices of a polygon and the triangulation options.
+// Returns the set of pieces, each one as the clockwise indices of its vertices.
+func Partition(vertices []vector.Vector2, options TriangulationOptions) (pieces [][]int, err error) {
+	ring, err := validate(vertices, options)
+	if err != nil {
+		return
+	}
+
+	if pieces, err = partition(vertices, ring); err != nil {
+		return
+	}
+	for _, piece := range pieces {
+		reverse(piece)
+	}
+	return
+}
+
+// partition decomposes the counter-clockwise ring into y-monotone pieces.
+// Returns the set of pieces, each one as the counter-clockwise indices of its vertices.
+func partition(vertices []vector.Vector2, ring []int) ([][]int, error) {
+	n := len(ring)
+
+	// the events are the vertices, from top to bottom
+	events := make([]int, n)
+	for i := range events {
+		events[i] = i
+	}
+	sort.Slice(events, func(a, b int) bool {
+		return above(vertices[ring[events[a]]], vertices[ring[events[b]]])
+	})
+
+	s := &status{
+		vertices: vertices,
+		ring:     ring,
+		helper:   make([]int, n),
+	}
+
+	var diagonals [][2]int
+	connected := make(map[[2]int]bool)
+	diagonal := func(i, j int) {
+		if i > j {
+			i, j = j, i
+		}
+		if !connected[[2]int{i, j}] {
+			connected[[2]int{i, j}] = true
+			diagonals = append(diagonals, [2]int{i, j})
+		}
+	}
+	// connects the vertex to the helper of the edge, if the helper is a merge vertex
+	connectMerge := func(i, edge int) {
+		if helper := s.helper[edge]; s.typeOf(helper) == merge {
+			diagonal(i, helper)
+		}
+	}
+
+	for _, i := range events {
+		previous := (i - 1 + n) % n
+		s.sweep = s.point(i)
+
+		// the edge i goes from the vertex i to the vertex i+1
+		switch s.typeOf(i) {
+		case start:
+			s.insert(i, i)
+		case end:
+			connectMerge(i, previous)
+			s.remove(previous)
+		case split:
+			left := s.leftOf()
+			if left < 0 {
+				return nil, ErrNotSimplePolygon
+			}
+			diagonal(i, s.helper[left])
+			s.helper[left] = i
+			s.insert(i, i)
+		case merge:
+			connectMerge(i, previous)
+			s.remove(previous)
+			left := s.leftOf()
+			if left < 0 {
+				return nil, ErrNotSimplePolygon
+			}
+			connectMerge(i, left)
+			s.helper[left] = i
+		case regular:
+			if above(s.point(previous), s.point(i)) {
+				// the interior of the polygon lies to the right of the vertex
+				connectMerge(i, previous)
+				s.remove(previous)
+				s.insert(i, i)
+			} else {
+				left := s.leftOf()
+				if left < 0 {
+					return nil, ErrNotSimplePolygon
+				}
+				connectMerge(i, left)
+				s.helper[left] = i
+			}
+		}
+	}
+
+	return splitFaces(vertices, ring, diagonals), nil
+}
+
+// typeOf classifies the vertex at the ring position i
+func (s *status) typeOf(i int) vertexType {
+	n := len(s.ring)
+	previous := s.point((i - 1 + n) % n)
+	current := s.point(i)
+	next := s.point((i + 1) % n)
+
+	previousBelow := above(current, previous)
+	nextBelow := above(current, next)
+	convex := predicates.Orient2D(previous, current, next) > 0
+
+	switch {
+	case previousBelow && nextBelow && convex:
+		return start
+	case previousBelow && nextBelow:
+		return split
+	case !previousBelow && !nextBelow && convex:
+		return end
+	case !previousBelow && !nextBelow:
+		return merge
+	}
+	return regular
+}
+
+// point returns the position of the vertex at the ring position i
+func (s *status) point(i int) vector.Vector2 {
+	return s.vertices[s.ring[i]]
+}
+
+// insert adds the edge to the status, with the given helper
+func (s *status) insert(edge, helper int) {
+	value := s.value(edge)
+	s.edges.Insert(edge, func(other int) bool {
+		return s.value(other) <= value
+	})
+	s.helper[edge] = helper
+}
+
+// remove deletes the edge from the status
+func (s *status) remove(edge int) {
+	value := s.value(edge)
+	s.edges.Remove(edge, func(other int) bool {
+		return s.value(other) < value
+	})
+}
+
+// leftOf returns the edge directly left of the sweep point, or -1 if there is none
+func (s *status) leftOf() int {
+	edge, ok := s.edges.Last(func(other int) bool {
+		return s.value(other) < s.sweep.X
+	})
+	if !ok {
+		return -1
+	}
+	return edge
+}
+
+// value returns the X coordinate of the edge at the sweep point
+func (s *status) value(edge int) float64 {
+	a := s.point(edge)
+	b := s.point((edge + 1) % len(s.ring))
+	switch {
+	case a.Y == b.Y:
+		// horizontal edges are only in the status while the sweep point is one of their endpoints
+		return mathf.Clamp(s.sweep.X, math.Min(a.X, b.X), math.Max(a.X, b.X))
+	case s.sweep.Y == a.Y:
+		return a.X
+	case s.sweep.Y == b.Y:
+		return b.X
+	}
+	return a.X + (s.sweep.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y)
+}
+
+// splitFaces splits the counter-clockwise ring along the diagonals.
+// Returns the faces, each one as the counter-clockwise indices of its vertices.
+func splitFaces(vertices []vector.Vector2, ring []int, diagonals [][2]int) (faces [][]int) {
+	n := len(ring)
+	if len(diagonals) == 0 {
+		return [][]int{append([]int{}, ring...)}
+	}
+
+	// neighbours of each ring position, sorted counter-clockwise by angle, and whether the half-edge
+	// towards each neighbour has been traced
+	neighbours := make([][]int, n)
+	traced := make([][]bool, n)
+	for i := range ring {
+		neighbours[i] = append(neighbours[i], (i+1)%n, (i-1+n)%n)
+	}
+	for _, d := range diagonals {
+		neighbours[d[0]] = append(neighbours[d[0]], d[1])
+		neighbours[d[1]] = append(neighbours[d[1]], d[0])
+	}
+	for i := range neighbours {
+		around := neighbours[i]
+		if len(around) > 2 {
+			origin := vertices[ring[i]]
+			angles := make([]float64, len(around))
+			for k, j := range around {
+				d := origin.To(vertices[ring[j]])
+				angles[k] = math.Atan2(d.Y, d.X)
+			}
+			sort.Sort(byAngle{around, angles})
+		}
+
+		// the outer side of the polygon edges is never traced
+		traced[i] = make([]bool, len(around))
+		for k, j := range around {
+			traced[i][k] = j == (i-1+n)%n
+		}
+	}
+
+	// tracing the faces, which keep the interior to the left of their edges
+	for i := range ring {
+		for k := range neighbours[i] {
+			from := i
+			slot := k
+			var face []int
+			for !traced[from][slot] {
+				traced[from][slot] = true
+				face = append(face, ring[from])
+
+				// the next edge is the first one clockwise from the edge coming back
+				to := neighbours[from][slot]
+				around := neighbours[to]
+				back := 0
+				for around[back] != from {
+					back++
+				}
+				from = to
+				slot = (back - 1 + len(around)) % len(around)
+			}
+			if len(face) > 0 {
+				faces = append(faces, face)
+			}
+		}
+	}
+	return
+}
+
+// byAngle sorts a set of neighbours by their angles
+type byAngle struct {
+	neighbours []int
+	angles     []float64
+}
+
+func (a byAngle) Len() int           { return len(a.neighbours) }
+func (a byAngle) Less(i, j int) bool { return a.angles[i] < a.angles[j] }
+func (a byAngle) Swap(i, j int) {
+	a.neighbours[i], a.neighbours[j] = a.neighbours[j], a.neighbours[i]
+	a.angles[i], a.angles[j] = a.angles[j], a.angles[i]
+}
+
+// above determines whether the point p comes before the point q in the sweep, from top to bottom
+func above(p, q vector.Vector2) bool {
+	return p.Y > q.Y || (p.Y == q.Y && p.X < q.X)
+}
+
+// reverse reverses a slice of indices
+func reverse(indices []int) {
+	for i, j := 0, len(indices)-1; i < j; i, j = i+1, j-1 {
+		indices[i], indices[j] = indices[j], indices[i]
+	}
+}
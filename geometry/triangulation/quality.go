@@ -0,0 +1,96 @@
+// Package triangulation provides quality metrics and refinement of triangulations,
+// such as the ones calculated by the earclipping, monotone and delaunay packages
+package triangulation
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// TriangleMetrics holds the quality metrics of a triangle
+type TriangleMetrics struct {
+	Area        float64 // area of the triangle
+	MinAngle    float64 // smallest interior angle, in radians
+	AspectRatio float64 // circumradius over twice the inradius, 1 for an equilateral triangle
+}
+
+// QualitySummary holds the aggregated quality metrics of a triangulation
+type QualitySummary struct {
+	TotalArea      float64 // sum of the areas of the triangles
+	MinArea        float64 // area of the smallest triangle
+	MinAngle       float64 // smallest interior angle of all triangles, in radians
+	MaxAspectRatio float64 // largest aspect ratio of all triangles
+}
+
+// ComputeTriangleMetrics calculates the quality metrics of the triangle abc.
+// A degenerate triangle has an infinite aspect ratio.
+func ComputeTriangleMetrics(a, b, c vector.Vector2) (metrics TriangleMetrics) {
+	ab := a.Distance(b)
+	bc := b.Distance(c)
+	ca := c.Distance(a)
+
+	metrics.Area = math.Abs(a.To(b).Cross(a.To(c))) / 2
+	metrics.MinAngle = math.Min(geometry.InteriorAngle(a, b, c), math.Min(geometry.InteriorAngle(b, c, a), geometry.InteriorAngle(c, a, b)))
+
+	if metrics.Area == 0 {
+		metrics.AspectRatio = math.Inf(1)
+		return
+	}
+	// R = abc / 4A and r = 2A / (a + b + c)
+	metrics.AspectRatio = ab * bc * ca * (ab + bc + ca) / (16 * metrics.Area * metrics.Area)
+	return
+}
+
+// ComputeMetrics calculates the quality metrics of every triangle of a triangulation.
+//
+// Receives the vertices and the set of indices of the vertices of the triangles.
+// Returns the metrics of each triangle, in the order of the triangles.
+func ComputeMetrics(vertices []vector.Vector2, triangles []int) (metrics []TriangleMetrics, err error) {
+	if err = validateTriangles(vertices, triangles); err != nil {
+		return
+	}
+
+	metrics = make([]TriangleMetrics, len(triangles)/3)
+	for i := range metrics {
+		a := vertices[triangles[3*i]]
+		b := vertices[triangles[3*i+1]]
+		c := vertices[triangles[3*i+2]]
+		metrics[i] = ComputeTriangleMetrics(a, b, c)
+	}
+	return
+}
+
+// Summarize aggregates the quality metrics of the triangles of a triangulation
+func Summarize(metrics []TriangleMetrics) (summary QualitySummary) {
+	if len(metrics) == 0 {
+		return
+	}
+
+	summary.MinArea = math.Inf(1)
+	summary.MinAngle = math.Inf(1)
+	for _, m := range metrics {
+		summary.TotalArea += m.Area
+		summary.MinArea = math.Min(summary.MinArea, m.Area)
+		summary.MinAngle = math.Min(summary.MinAngle, m.MinAngle)
+		summary.MaxAspectRatio = math.Max(summary.MaxAspectRatio, m.AspectRatio)
+	}
+	return
+}
+
+// validateTriangles checks that the index list describes triangles over the vertex list
+func validateTriangles(vertices []vector.Vector2, triangles []int) error {
+	if vertices == nil || triangles == nil {
+		return ErrNilVertices
+	}
+	if len(triangles)%3 != 0 {
+		return ErrInvalidIndexCount
+	}
+	for _, i := range triangles {
+		if i < 0 || i >= len(vertices) {
+			return ErrIndexOutOfRange
+		}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package triangulation
+
+import (
+	"github.com/mindera-gaming/go-math/geometry/triangulation/delaunay"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// RefinementOptions is the structure that defines the refinement options
+type RefinementOptions struct {
+	InsertSteinerPoints bool    // set to true to insert new vertices, instead of only flipping edges
+	MinAngle            float64 // minimum angle targeted by the inserted vertices, in radians
+	MaxSteinerPoints    int     // maximum number of inserted vertices, zero means ten times the number of vertices
+}
+
+// Refine improves the quality of a triangulation.
+//
+// Firstly, the interior edges are flipped until the triangulation is a constrained Delaunay triangulation,
+// which maximises its smallest angle. Then, if required, new vertices are inserted following Ruppert's algorithm
+// until no triangle has an angle smaller than the minimum angle. The boundary of the triangulation is preserved,
+// although its edges may be split by the inserted vertices.
+//
+// Receives the vertices and the set of indices of the vertices of the triangles.
+// Returns the original vertices followed by the inserted ones, and the set of indices of the vertices of
+// the refined triangles, in clockwise order.
+func Refine(vertices []vector.Vector2, triangles []int, options RefinementOptions) (refinedVertices []vector.Vector2, refinedTriangles []int, err error) {
+	if err = validateTriangles(vertices, triangles); err != nil {
+		return
+	}
+
+	t, err := delaunay.FromTriangles(vertices, triangles)
+	if err != nil {
+		err = ErrInvalidTriangulation
+		return
+	}
+
+	if options.InsertSteinerPoints {
+		maxSteinerPoints := options.MaxSteinerPoints
+		if maxSteinerPoints <= 0 {
+			maxSteinerPoints = 10 * len(vertices)
+		}
+		t.Refine(options.MinAngle, maxSteinerPoints)
+	} else {
+		t.RestoreDelaunay()
+	}
+
+	return t.Points, t.Triangles, nil
+}
@@ -0,0 +1,406 @@
+package geometry
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	"github.com/mindera-gaming/go-math/geometry/sweepline"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Using Fortune's sweep-line algorithm for Voronoi diagrams, as described in:
+// Computational Geometry: Algorithms and Applications (de Berg et al.), chapter 7
+// The sweep-line moves from left to right, and the beach line holds the parabolic arcs closest to it from bottom to
+// top. The sweep finds the sites whose cells are adjacent, and each cell is then built by clipping the bounding
+// rectangle with the bisectors between its site and the adjacent sites.
+
+// voronoiTolerance is the distance, relative to the magnitude of the coordinates, below which two points coincide
+const voronoiTolerance = 1e-9
+
+// VoronoiCell defines the region of a Voronoi diagram closer to its site than to any other site.
+type VoronoiCell struct {
+	Vertices   []vector.Vector2 // vertices of the region in Clockwise order, empty if it lies outside the bounds
+	Neighbours []int            // indices of the sites whose cells share an edge with the cell, in ascending order
+}
+
+// VoronoiDiagram computes the Voronoi diagram of the sites, using Fortune's algorithm, clipped to the rectangle
+// between the min and max corners.
+//
+// Returns the cell of each site, in the order of the sites. A repeated site gets an empty cell, as the region
+// belongs to its first occurrence.
+func VoronoiDiagram(sites []vector.Vector2, min, max vector.Vector2) []VoronoiCell {
+	magnitude := math.Max(1, math.Max(math.Max(math.Abs(min.X), math.Abs(min.Y)), math.Max(math.Abs(max.X), math.Abs(max.Y))))
+	for _, s := range sites {
+		magnitude = math.Max(magnitude, math.Max(math.Abs(s.X), math.Abs(s.Y)))
+	}
+	tolerance := voronoiTolerance * magnitude
+
+	sorted := sortUnique(sites)
+	f := fortune{sites: sites, tolerance: tolerance, seed: 2463534242, adjacent: make(map[[2]int]bool)}
+	f.run(sorted)
+
+	neighbours := make([][]int, len(sites))
+	for pair := range f.adjacent {
+		neighbours[pair[0]] = append(neighbours[pair[0]], pair[1])
+		neighbours[pair[1]] = append(neighbours[pair[1]], pair[0])
+	}
+
+	cells := make([]VoronoiCell, len(sites))
+	shared := make(map[[2]int]bool)
+	for _, i := range sorted {
+		var adjacent []int
+		cells[i].Vertices, adjacent = clipVoronoiCell(sites, i, neighbours[i], min, max, tolerance)
+		for _, j := range adjacent {
+			shared[orderedPair(i, j)] = true
+		}
+	}
+
+	// the neighbours are made symmetric, as the rounding errors may hide a short edge from one of the cells
+	for pair := range shared {
+		cells[pair[0]].Neighbours = append(cells[pair[0]].Neighbours, pair[1])
+		cells[pair[1]].Neighbours = append(cells[pair[1]].Neighbours, pair[0])
+	}
+	for i := range cells {
+		sort.Ints(cells[i].Neighbours)
+	}
+	return cells
+}
+
+// LloydRelaxation moves every site to the centroid of its Voronoi cell, clipped to the rectangle between the min and
+// max corners, for the given number of iterations, spreading the sites more evenly.
+// Returns the relaxed sites. The sites with an empty cell keep their position.
+func LloydRelaxation(sites []vector.Vector2, min, max vector.Vector2, iterations int) []vector.Vector2 {
+	relaxed := append([]vector.Vector2{}, sites...)
+	for i := 0; i < iterations; i++ {
+		for j, cell := range VoronoiDiagram(relaxed, min, max) {
+			if len(cell.Vertices) != 0 {
+				relaxed[j] = computeCentroid(cell.Vertices)
+			}
+		}
+	}
+	return relaxed
+}
+
+// clipVoronoiCell clips the rectangle between the min and max corners by the bisectors between the site i and each
+// candidate site, keeping the side of the site i.
+// Returns the vertices of the cell and the candidate sites whose bisector bounds one of its edges
+func clipVoronoiCell(sites []vector.Vector2, i int, candidates []int, min, max vector.Vector2, tolerance float64) ([]vector.Vector2, []int) {
+	// each vertex holds the site whose bisector bounds the edge starting at it, or -1 for the rectangle
+	ring := []vector.Vector2{min, {X: min.X, Y: max.Y}, max, {X: max.X, Y: min.Y}}
+	labels := []int{-1, -1, -1, -1}
+	site := sites[i]
+	for _, j := range candidates {
+		normal := site.To(sites[j]).Normalized()
+		middle := vector.Lerp(site, sites[j], 0.5)
+
+		var clippedRing []vector.Vector2
+		var clippedLabels []int
+		for k, current := range ring {
+			next := ring[(k+1)%len(ring)]
+			d0 := middle.To(current).Dot(normal)
+			d1 := middle.To(next).Dot(normal)
+			if d0 <= 0 {
+				clippedRing = append(clippedRing, current)
+				clippedLabels = append(clippedLabels, labels[k])
+				if d1 > 0 {
+					clippedRing = append(clippedRing, vector.LerpUnclamped(current, next, d0/(d0-d1)))
+					clippedLabels = append(clippedLabels, j)
+				}
+			} else if d1 <= 0 {
+				clippedRing = append(clippedRing, vector.LerpUnclamped(current, next, d0/(d0-d1)))
+				clippedLabels = append(clippedLabels, labels[k])
+			}
+		}
+		ring, labels = clippedRing, clippedLabels
+		if len(ring) == 0 {
+			return nil, nil
+		}
+	}
+
+	// the edges shorter than the tolerance are removed, the next edge starting in their place
+	var vertices []vector.Vector2
+	var neighbours []int
+	for k, v := range ring {
+		if v.Distance(ring[(k+1)%len(ring)]) > tolerance {
+			vertices = append(vertices, v)
+			neighbours = append(neighbours, labels[k])
+		}
+	}
+	if len(vertices) < 3 {
+		return nil, nil
+	}
+	if area, _ := ComputePolygonArea(vertices); area <= tolerance*tolerance {
+		return nil, nil
+	}
+
+	adjacent := neighbours[:0]
+	for _, j := range neighbours {
+		if j >= 0 {
+			adjacent = append(adjacent, j)
+		}
+	}
+	return vertices, adjacent
+}
+
+// orderedPair returns the pair of indices with the lowest one first
+func orderedPair(i, j int) [2]int {
+	if i > j {
+		return [2]int{j, i}
+	}
+	return [2]int{i, j}
+}
+
+// fortune holds the state of Fortune's algorithm
+type fortune struct {
+	sites     []vector.Vector2
+	tolerance float64
+	seed      uint32               // state of the generator of the priorities of the arcs
+	root      *arc                 // root of the treap of the beach line
+	queue     sweepline.EventQueue // points where the arcs vanish
+	adjacent  map[[2]int]bool      // pairs of sites whose cells are adjacent
+}
+
+// arc defines a parabolic arc of the beach line, stored in a treap ordered from bottom to top
+type arc struct {
+	site                int
+	previous, next      *arc // arcs below and above
+	parent, left, right *arc
+	priority            uint32
+	event               *sweepline.QueuedEvent // event where the arc vanishes, if any
+}
+
+// run sweeps the sorted unique sites
+func (f *fortune) run(sorted []int) {
+	for next := 0; next < len(sorted) || f.queue.Len() != 0; {
+		if next < len(sorted) && (f.queue.Len() == 0 || !lessPoint(f.queue.Peek().Point, f.sites[sorted[next]])) {
+			f.addSite(sorted[next])
+			next++
+		} else {
+			f.removeArc(f.queue.Pop().Data.(*arc))
+		}
+	}
+}
+
+// addSite splits the arc above the site, or inserts the arc of the site between the arcs whose breakpoint lies on it
+func (f *fortune) addSite(site int) {
+	a := &arc{site: site, priority: f.random()}
+	lower, upper := f.find(f.sites[site])
+	switch {
+	case lower == nil && upper == nil:
+		f.root = a
+	case lower == upper:
+		f.cancelEvent(lower)
+		split := &arc{site: lower.site, priority: f.random()}
+		f.insert(a, lower, lower.next)
+		f.insert(split, a, a.next)
+		f.adjacent[orderedPair(lower.site, site)] = true
+		f.checkCircle(lower)
+		f.checkCircle(split)
+	default:
+		f.insert(a, lower, upper)
+		for _, neighbour := range []*arc{lower, upper} {
+			if neighbour != nil {
+				f.cancelEvent(neighbour)
+				f.adjacent[orderedPair(neighbour.site, site)] = true
+				f.checkCircle(neighbour)
+			}
+		}
+	}
+}
+
+// removeArc removes the vanishing arc, making the arcs around it adjacent
+func (f *fortune) removeArc(a *arc) {
+	lower, upper := a.previous, a.next
+	a.event = nil
+	f.cancelEvent(lower)
+	f.cancelEvent(upper)
+	f.adjacent[orderedPair(lower.site, upper.site)] = true
+	f.remove(a)
+	f.checkCircle(lower)
+	f.checkCircle(upper)
+}
+
+// checkCircle queues the event where the arc vanishes, if the breakpoints around it converge
+func (f *fortune) checkCircle(a *arc) {
+	if a.previous == nil || a.next == nil || a.previous.site == a.next.site {
+		return
+	}
+	p, q, r := f.sites[a.previous.site], f.sites[a.site], f.sites[a.next.site]
+	if predicates.Orient2D(p, q, r) >= 0 {
+		return
+	}
+
+	// the sweep-line leaves the circle through the sites of the three arcs where the middle one vanishes
+	center := Circumcenter(p, q, r)
+	a.event = f.queue.Push(vector.Vector2{X: center.X + center.Distance(q), Y: center.Y}, a)
+}
+
+// cancelEvent removes the queued event where the arc vanishes, if any
+func (f *fortune) cancelEvent(a *arc) {
+	if a != nil && a.event != nil {
+		f.queue.Remove(a.event)
+		a.event = nil
+	}
+}
+
+// find returns the arcs below and above the point p, with the sweep-line at its X.
+// Both are the same arc if the point lies inside it, and either may be nil at the ends of the beach line
+func (f *fortune) find(p vector.Vector2) (lower, upper *arc) {
+	for a := f.root; a != nil; {
+		bottom, top := f.breakpoints(a, p.X)
+		switch {
+		case p.Y < bottom-f.tolerance:
+			if a.left == nil {
+				return a.previous, a
+			}
+			a = a.left
+		case p.Y > top+f.tolerance:
+			if a.right == nil {
+				return a, a.next
+			}
+			a = a.right
+		case p.Y <= bottom+f.tolerance:
+			return a.previous, a
+		case p.Y >= top-f.tolerance:
+			return a, a.next
+		default:
+			return a, a
+		}
+	}
+	return nil, nil
+}
+
+// breakpoints returns the Y coordinates where the arc starts and ends, with the sweep-line at the directrix.
+// The arc of a site lying on the sweep-line is a horizontal ray, which starts and ends at the site
+func (f *fortune) breakpoints(a *arc, directrix float64) (bottom, top float64) {
+	site := f.sites[a.site]
+	bottom, top = math.Inf(-1), math.Inf(1)
+	if site.X == directrix {
+		bottom, top = site.Y, site.Y
+	}
+	if a.previous != nil {
+		bottom = breakpoint(f.sites[a.previous.site], site, directrix)
+	}
+	if a.next != nil {
+		top = breakpoint(site, f.sites[a.next.site], directrix)
+	}
+	return
+}
+
+// breakpoint returns the Y coordinate where the arc of the site p, below, meets the arc of the site q, above,
+// with the sweep-line at the directrix
+func breakpoint(p, q vector.Vector2, directrix float64) float64 {
+	// each arc holds the points x = ((y - s.Y)² + s.X² - directrix²) / 2(s.X - directrix) of its site s
+	dq := q.X - directrix
+	if dq == 0 {
+		return q.Y
+	}
+	dp := p.X - directrix
+	if dp == 0 {
+		return p.Y
+	}
+
+	h := p.Y - q.Y
+	a := 1/dq - 1/dp
+	b := h / dp
+	if a == 0 {
+		return (p.Y + q.Y) / 2
+	}
+	discriminant := b*b - 2*a*(h*h/(-2*dp)-p.X+dp/2+q.X-dq/2)
+	return (-b+math.Sqrt(math.Max(0, discriminant)))/a + q.Y
+}
+
+// random returns the next priority of an arc, using a xorshift generator
+func (f *fortune) random() uint32 {
+	f.seed ^= f.seed << 13
+	f.seed ^= f.seed >> 17
+	f.seed ^= f.seed << 5
+	return f.seed
+}
+
+// insert links the arc to the beach line between the arcs below and above it, either of which may be nil
+func (f *fortune) insert(a, lower, upper *arc) {
+	a.previous, a.next = lower, upper
+	if lower != nil {
+		lower.next = a
+	}
+	if upper != nil {
+		upper.previous = a
+	}
+
+	// the arc becomes the right child of the arc below it or, if it has one, the left child of the arc above it
+	switch {
+	case lower != nil && lower.right == nil:
+		lower.right = a
+		a.parent = lower
+	case upper != nil:
+		upper.left = a
+		a.parent = upper
+	default:
+		f.root = a
+	}
+	for a.parent != nil && a.parent.priority < a.priority {
+		f.rotateUp(a)
+	}
+}
+
+// remove unlinks the arc from the beach line
+func (f *fortune) remove(a *arc) {
+	if a.previous != nil {
+		a.previous.next = a.next
+	}
+	if a.next != nil {
+		a.next.previous = a.previous
+	}
+
+	// the arc is rotated down until it has a single child, which takes its place
+	for a.left != nil && a.right != nil {
+		if a.left.priority > a.right.priority {
+			f.rotateUp(a.left)
+		} else {
+			f.rotateUp(a.right)
+		}
+	}
+	child := a.left
+	if child == nil {
+		child = a.right
+	}
+	if child != nil {
+		child.parent = a.parent
+	}
+	f.replaceChild(a.parent, a, child)
+}
+
+// rotateUp rotates the arc with its parent, making it the parent of its former parent
+func (f *fortune) rotateUp(a *arc) {
+	parent := a.parent
+	if parent.left == a {
+		parent.left = a.right
+		if a.right != nil {
+			a.right.parent = parent
+		}
+		a.right = parent
+	} else {
+		parent.right = a.left
+		if a.left != nil {
+			a.left.parent = parent
+		}
+		a.left = parent
+	}
+	a.parent = parent.parent
+	parent.parent = a
+	f.replaceChild(a.parent, parent, a)
+}
+
+// replaceChild replaces the child old of the parent, or the root if the parent is nil, by the arc a
+func (f *fortune) replaceChild(parent, old, a *arc) {
+	switch {
+	case parent == nil:
+		f.root = a
+	case parent.left == old:
+		parent.left = a
+	default:
+		parent.right = a
+	}
+}
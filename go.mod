@@ -2,7 +2,4 @@ module github.com/mindera-gaming/go-math
 
 go 1.16
 
-require (
-	github.com/google/uuid v1.2.0
-	github.com/mindera-gaming/go-data-structure v0.2.0
-)
+require github.com/google/uuid v1.2.0
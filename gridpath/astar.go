@@ -0,0 +1,141 @@
+package gridpath
+
+import (
+	"container/heap"
+	"math"
+)
+
+// SearchOptions is the structure that defines the path search options
+type SearchOptions struct {
+	Connectivity Connectivity // moves allowed between the cells, FourConnected by default
+	Heuristic    Heuristic    // estimate of the cost to the goal, Manhattan by default
+}
+
+// FindPath finds the cheapest path from the start to the goal, using the A* algorithm.
+// The path is the cheapest one as long as the heuristic never overestimates the cost, which holds when every step
+// costs at least its length and the heuristic suits the connectivity.
+// Returns every cell of the path, from the start to the goal, or an error if either point cannot be walked through
+// or if the goal cannot be reached
+func (g *Grid) FindPath(start, goal Point, options SearchOptions) ([]Point, error) {
+	if !g.IsWalkable(start) || !g.IsWalkable(goal) {
+		return nil, ErrNotWalkable
+	}
+
+	s := newSearch(g)
+	s.open(g.index(start), -1, 0, options.Heuristic.Estimate(start, goal))
+	target := g.index(goal)
+	for s.queue.Len() != 0 {
+		current, ok := s.next()
+		if !ok {
+			continue
+		}
+		if current == target {
+			return s.path(target), nil
+		}
+
+		p := g.point(current)
+		g.neighbours(p, options.Connectivity, func(q Point) {
+			cost := s.costs[current] + g.StepCost(p, q)
+			if !math.IsInf(cost, 1) {
+				s.open(g.index(q), current, cost, options.Heuristic.Estimate(q, goal))
+			}
+		})
+	}
+	return nil, ErrNoPath
+}
+
+// search holds the state of a best-first search over the cells of a grid
+type search struct {
+	grid    *Grid
+	costs   []float64
+	parents []int
+	closed  []bool
+	queue   openQueue
+}
+
+// newSearch creates a search where no cell has been reached
+func newSearch(g *Grid) *search {
+	s := &search{
+		grid:    g,
+		costs:   make([]float64, g.size()),
+		parents: make([]int, g.size()),
+		closed:  make([]bool, g.size()),
+	}
+	for i := range s.costs {
+		s.costs[i] = math.Inf(1)
+		s.parents[i] = -1
+	}
+	return s
+}
+
+// open queues the cell if it is reached more cheaply through the parent, with the estimated cost to the goal
+func (s *search) open(cell, parent int, cost, estimate float64) {
+	if s.closed[cell] || cost >= s.costs[cell] {
+		return
+	}
+	s.costs[cell] = cost
+	s.parents[cell] = parent
+	heap.Push(&s.queue, openEntry{cell: cell, cost: cost, priority: cost + estimate})
+}
+
+// next removes the most promising cell from the queue and closes it. Returns false if it was already closed
+func (s *search) next() (int, bool) {
+	cell := heap.Pop(&s.queue).(openEntry).cell
+	if s.closed[cell] {
+		return cell, false
+	}
+	s.closed[cell] = true
+	return cell, true
+}
+
+// path returns the cells leading to the cell, following their parents from the start
+func (s *search) path(cell int) []Point {
+	var path []Point
+	for ; cell != -1; cell = s.parents[cell] {
+		path = append(path, s.grid.point(cell))
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// openEntry is a cell queued by a best-first search
+type openEntry struct {
+	cell     int
+	cost     float64 // cost to reach the cell
+	priority float64 // cost to reach the cell plus the estimated cost to the goal
+}
+
+// openQueue is a priority queue of cells, ordered by their priority. The ties prefer the cells closer to the goal,
+// and then the lowest index
+type openQueue []openEntry
+
+func (q openQueue) Len() int {
+	return len(q)
+}
+
+func (q openQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	if q[i].cost != q[j].cost {
+		return q[i].cost > q[j].cost
+	}
+	return q[i].cell < q[j].cell
+}
+
+func (q openQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *openQueue) Push(x interface{}) {
+	*q = append(*q, x.(openEntry))
+}
+
+func (q *openQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
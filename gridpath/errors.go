@@ -0,0 +1,8 @@
+package gridpath
+
+import "errors"
+
+var (
+	ErrNotWalkable = errors.New("The point lies outside the grid or cannot be walked through.")
+	ErrNoPath      = errors.New("There is no path between the points.")
+)
@@ -0,0 +1,115 @@
+package gridpath
+
+import "math"
+
+// DistanceMap holds the cost of the cheapest path from every cell of a grid to the nearest goal, also known as a
+// Dijkstra map
+type DistanceMap struct {
+	grid         *Grid
+	connectivity Connectivity
+	costs        []float64
+}
+
+// DistanceMap computes the cost of the cheapest path from every cell to the nearest goal, using Dijkstra's
+// algorithm from the goals. The goals which cannot be walked through are ignored
+func (g *Grid) DistanceMap(goals []Point, connectivity Connectivity) *DistanceMap {
+	s := newSearch(g)
+	for _, goal := range goals {
+		if g.IsWalkable(goal) {
+			s.open(g.index(goal), -1, 0, 0)
+		}
+	}
+	for s.queue.Len() != 0 {
+		current, ok := s.next()
+		if !ok {
+			continue
+		}
+
+		// the paths lead towards the goals, so each neighbour is reached through a step into the current cell
+		p := g.point(current)
+		g.neighbours(p, connectivity, func(q Point) {
+			cost := s.costs[current] + g.StepCost(q, p)
+			if !math.IsInf(cost, 1) {
+				s.open(g.index(q), current, cost, 0)
+			}
+		})
+	}
+	return &DistanceMap{grid: g, connectivity: connectivity, costs: s.costs}
+}
+
+// Cost returns the cost of the cheapest path from the cell to the nearest goal, or positive infinity if no goal can
+// be reached from it
+func (d *DistanceMap) Cost(p Point) float64 {
+	if !d.grid.Contains(p) {
+		return math.Inf(1)
+	}
+	return d.costs[d.grid.index(p)]
+}
+
+// FlowField holds, for every cell of a grid, the step towards the nearest goal along the cheapest path, so that any
+// number of agents can move towards the goals by following it
+type FlowField struct {
+	*DistanceMap
+	steps []int8 // index of the direction of the step from each cell, or -1 if there is none
+}
+
+// FlowField computes the step towards the nearest goal from every cell, following the cheapest paths found by the
+// distance map of the goals. The goals which cannot be walked through are ignored
+func (g *Grid) FlowField(goals []Point, connectivity Connectivity) *FlowField {
+	f := &FlowField{DistanceMap: g.DistanceMap(goals, connectivity), steps: make([]int8, g.size())}
+	count := 4
+	if connectivity == EightConnected {
+		count = 8
+	}
+
+	for i := range f.steps {
+		f.steps[i] = -1
+		p := g.point(i)
+		best := f.costs[i]
+		if best == 0 || math.IsInf(best, 1) {
+			continue
+		}
+
+		// the step leads to the neighbour with the lowest cost to the goals, including the cost of the step
+		best = math.Inf(1)
+		for k, d := range directions[:count] {
+			q := p.Add(d)
+			if !g.IsWalkable(q) || !g.canStep(p, d) {
+				continue
+			}
+			if cost := g.StepCost(p, q) + f.costs[g.index(q)]; cost < best {
+				best = cost
+				f.steps[i] = int8(k)
+			}
+		}
+	}
+	return f
+}
+
+// Step returns the step, a unit offset in one of the eight directions, from the cell towards the nearest goal.
+// Returns false if the cell is a goal or if no goal can be reached from it
+func (f *FlowField) Step(p Point) (Point, bool) {
+	if !f.grid.Contains(p) {
+		return Point{}, false
+	}
+	k := f.steps[f.grid.index(p)]
+	if k < 0 {
+		return Point{}, false
+	}
+	return directions[k], true
+}
+
+// Path follows the flow field from the cell until it reaches a goal.
+// Returns every cell of the path, from the cell to the goal, or ErrNoPath if no goal can be reached from it
+func (f *FlowField) Path(from Point) ([]Point, error) {
+	if math.IsInf(f.Cost(from), 1) {
+		return nil, ErrNoPath
+	}
+
+	path := []Point{from}
+	for step, ok := f.Step(from); ok; step, ok = f.Step(from) {
+		from = from.Add(step)
+		path = append(path, from)
+	}
+	return path, nil
+}
@@ -0,0 +1,165 @@
+// Package gridpath provides pathfinding over integer 2D grids: A*, Jump Point Search, Dijkstra maps and flow fields
+package gridpath
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/mathi"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Point represents the integer coordinates of a cell of a grid
+type Point struct {
+	X int64 `json:"x"`
+	Y int64 `json:"y"`
+}
+
+// NewPointFromVector2 returns the cell holding the position, rounding its coordinates down
+func NewPointFromVector2(v vector.Vector2) Point {
+	return Point{X: int64(math.Floor(v.X)), Y: int64(math.Floor(v.Y))}
+}
+
+// Vector2 returns the coordinates of the cell as a vector
+func (p Point) Vector2() vector.Vector2 {
+	return vector.Vector2{X: float64(p.X), Y: float64(p.Y)}
+}
+
+// Add returns the sum of both points
+func (p Point) Add(other Point) Point {
+	return Point{X: p.X + other.X, Y: p.Y + other.Y}
+}
+
+// Sub returns the difference between both points
+func (p Point) Sub(other Point) Point {
+	return Point{X: p.X - other.X, Y: p.Y - other.Y}
+}
+
+// ToVector2 converts a path of cells into a path of vectors
+func ToVector2(path []Point) []vector.Vector2 {
+	vectors := make([]vector.Vector2, len(path))
+	for i, p := range path {
+		vectors[i] = p.Vector2()
+	}
+	return vectors
+}
+
+// Connectivity defines an enumerator representing the moves allowed between the cells of a grid
+type Connectivity byte
+
+const (
+	// FourConnected moves horizontally and vertically
+	FourConnected Connectivity = iota
+	// EightConnected also moves diagonally, as long as both cells beside the diagonal are walkable,
+	// so the corners of the blocked cells are never cut
+	EightConnected
+)
+
+// Heuristic defines an enumerator representing the estimate of the cost between two cells
+type Heuristic byte
+
+const (
+	// Manhattan is the sum of the horizontal and vertical distances, suited to four-connected grids
+	Manhattan Heuristic = iota
+	// Octile is the length of the shortest path with diagonal moves, suited to eight-connected grids
+	Octile
+)
+
+// Estimate returns the estimated cost between the cells, assuming every step costs at least its length
+func (h Heuristic) Estimate(a, b Point) float64 {
+	dx := float64(mathi.Max(a.X-b.X, b.X-a.X))
+	dy := float64(mathi.Max(a.Y-b.Y, b.Y-a.Y))
+	if h == Octile {
+		return math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)
+	}
+	return dx + dy
+}
+
+// WalkableFunc reports whether the cell can be walked through
+type WalkableFunc func(p Point) bool
+
+// CostFunc returns the cost of a step between adjacent cells, which must be positive. An infinite cost forbids
+// the step
+type CostFunc func(from, to Point) float64
+
+// directions holds the horizontal and vertical steps, followed by the diagonal ones
+var directions = [8]Point{{1, 0}, {0, 1}, {-1, 0}, {0, -1}, {1, 1}, {-1, 1}, {-1, -1}, {1, -1}}
+
+// Grid represents a rectangular grid of cells, from (0, 0) to (width-1, height-1), whose walkability and costs are
+// given by callbacks
+type Grid struct {
+	width    int64
+	height   int64
+	walkable WalkableFunc
+	cost     CostFunc
+}
+
+// NewGrid creates a grid with the given size. A nil walkability callback makes every cell walkable, and a nil cost
+// callback makes every step cost its length, 1 for horizontal and vertical steps and √2 for diagonal ones
+func NewGrid(width, height int64, walkable WalkableFunc, cost CostFunc) *Grid {
+	return &Grid{width: width, height: height, walkable: walkable, cost: cost}
+}
+
+// Width returns the number of columns of the grid
+func (g *Grid) Width() int64 {
+	return g.width
+}
+
+// Height returns the number of rows of the grid
+func (g *Grid) Height() int64 {
+	return g.height
+}
+
+// Contains checks if the cell lies inside the grid
+func (g *Grid) Contains(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < g.width && p.Y < g.height
+}
+
+// IsWalkable checks if the cell lies inside the grid and can be walked through
+func (g *Grid) IsWalkable(p Point) bool {
+	return g.Contains(p) && (g.walkable == nil || g.walkable(p))
+}
+
+// StepCost returns the cost of the step between the adjacent cells
+func (g *Grid) StepCost(from, to Point) float64 {
+	if g.cost != nil {
+		return g.cost(from, to)
+	}
+	if from.X != to.X && from.Y != to.Y {
+		return math.Sqrt2
+	}
+	return 1
+}
+
+// neighbours calls the function f with every walkable cell reachable from the cell p in a single step
+func (g *Grid) neighbours(p Point, connectivity Connectivity, f func(q Point)) {
+	count := 4
+	if connectivity == EightConnected {
+		count = 8
+	}
+	for _, d := range directions[:count] {
+		q := p.Add(d)
+		if g.IsWalkable(q) && g.canStep(p, d) {
+			f(q)
+		}
+	}
+}
+
+// canStep checks if the step in the direction d leaving the cell p does not cut the corner of a blocked cell
+func (g *Grid) canStep(p, d Point) bool {
+	return d.X == 0 || d.Y == 0 || (g.IsWalkable(Point{X: p.X + d.X, Y: p.Y}) && g.IsWalkable(Point{X: p.X, Y: p.Y + d.Y}))
+}
+
+// index returns the position of the cell in the arrays covering the grid
+func (g *Grid) index(p Point) int {
+	return int(p.Y*g.width + p.X)
+}
+
+// point returns the cell at the position of the arrays covering the grid
+func (g *Grid) point(i int) Point {
+	return Point{X: int64(i) % g.width, Y: int64(i) / g.width}
+}
+
+// size returns the number of cells of the grid
+func (g *Grid) size() int {
+	return int(g.width * g.height)
+}
@@ -0,0 +1,145 @@
+package gridpath
+
+import "github.com/mindera-gaming/go-math/mathi"
+
+// Using the Jump Point Search algorithm, as described in:
+// Online Graph Pruning for Pathfinding on Grid Maps (Harabor and Grastien), adapted to moves which never cut the
+// corners of the blocked cells.
+// Instead of opening every neighbour, the search jumps along straight and diagonal lines until it reaches the goal
+// or a cell with a forced neighbour, one that cannot be reached as cheaply without passing through that cell.
+
+// JumpPointSearch finds the shortest path from the start to the goal on an eight-connected grid, using the Jump Point
+// Search algorithm. It opens far fewer cells than FindPath, which pays off on large grids with obstacles, but assumes
+// that every step costs its length, so the cost callback of the grid is ignored.
+// Returns every cell of the path, from the start to the goal, or an error if either point cannot be walked through
+// or if the goal cannot be reached
+func (g *Grid) JumpPointSearch(start, goal Point) ([]Point, error) {
+	if !g.IsWalkable(start) || !g.IsWalkable(goal) {
+		return nil, ErrNotWalkable
+	}
+
+	s := newSearch(g)
+	s.open(g.index(start), -1, 0, Octile.Estimate(start, goal))
+	target := g.index(goal)
+	for s.queue.Len() != 0 {
+		current, ok := s.next()
+		if !ok {
+			continue
+		}
+		if current == target {
+			return expandJumps(s.path(target)), nil
+		}
+
+		p := g.point(current)
+		var parent *Point
+		if s.parents[current] != -1 {
+			q := g.point(s.parents[current])
+			parent = &q
+		}
+		for _, d := range g.prunedDirections(p, parent) {
+			if jump, ok := g.jump(p.Add(d), d, goal); ok {
+				s.open(g.index(jump), current, s.costs[current]+Octile.Estimate(p, jump), Octile.Estimate(jump, goal))
+			}
+		}
+	}
+	return nil, ErrNoPath
+}
+
+// prunedDirections returns the directions worth searching from the cell p, reached from the parent.
+// Every direction is searched from the start, which has no parent
+func (g *Grid) prunedDirections(p Point, parent *Point) []Point {
+	var result []Point
+	add := func(d Point) {
+		if g.IsWalkable(p.Add(d)) && g.canStep(p, d) {
+			result = append(result, d)
+		}
+	}
+	if parent == nil {
+		for _, d := range directions {
+			add(d)
+		}
+		return result
+	}
+
+	d := direction(*parent, p)
+	switch {
+	case d.X != 0 && d.Y != 0:
+		// the natural neighbours of a diagonal move are the diagonal itself and both of its components
+		add(Point{X: d.X})
+		add(Point{Y: d.Y})
+		add(d)
+	case d.X != 0:
+		// the cells beside a horizontal move are searched as well, as the diagonal moves cannot cut their corners
+		add(d)
+		add(Point{X: d.X, Y: 1})
+		add(Point{X: d.X, Y: -1})
+		add(Point{Y: 1})
+		add(Point{Y: -1})
+	default:
+		add(d)
+		add(Point{X: 1, Y: d.Y})
+		add(Point{X: -1, Y: d.Y})
+		add(Point{X: 1})
+		add(Point{X: -1})
+	}
+	return result
+}
+
+// jump moves from the cell p in the direction d until it reaches the goal or a jump point.
+// Returns false if it is blocked first
+func (g *Grid) jump(p, d, goal Point) (Point, bool) {
+	for {
+		if !g.IsWalkable(p) {
+			return Point{}, false
+		}
+		if p == goal {
+			return p, true
+		}
+
+		switch {
+		case d.X != 0 && d.Y != 0:
+			// a diagonal move stops where one of its components finds a jump point
+			if _, ok := g.jump(p.Add(Point{X: d.X}), Point{X: d.X}, goal); ok {
+				return p, true
+			}
+			if _, ok := g.jump(p.Add(Point{Y: d.Y}), Point{Y: d.Y}, goal); ok {
+				return p, true
+			}
+		case d.X != 0:
+			// a horizontal move stops beside the end of a wall, whose cells may now be reached diagonally
+			if (g.IsWalkable(Point{X: p.X, Y: p.Y - 1}) && !g.IsWalkable(Point{X: p.X - d.X, Y: p.Y - 1})) ||
+				(g.IsWalkable(Point{X: p.X, Y: p.Y + 1}) && !g.IsWalkable(Point{X: p.X - d.X, Y: p.Y + 1})) {
+				return p, true
+			}
+		default:
+			if (g.IsWalkable(Point{X: p.X - 1, Y: p.Y}) && !g.IsWalkable(Point{X: p.X - 1, Y: p.Y - d.Y})) ||
+				(g.IsWalkable(Point{X: p.X + 1, Y: p.Y}) && !g.IsWalkable(Point{X: p.X + 1, Y: p.Y - d.Y})) {
+				return p, true
+			}
+		}
+
+		if !g.canStep(p, d) {
+			return Point{}, false
+		}
+		p = p.Add(d)
+	}
+}
+
+// direction returns the unit step leading from the cell a towards the cell b, along a straight or diagonal line
+func direction(a, b Point) Point {
+	d := b.Sub(a)
+	return Point{X: mathi.Clamp(d.X, -1, 1), Y: mathi.Clamp(d.Y, -1, 1)}
+}
+
+// expandJumps returns every cell along the straight and diagonal lines between the jump points
+func expandJumps(jumps []Point) []Point {
+	path := []Point{jumps[0]}
+	for i := 1; i < len(jumps); i++ {
+		d := direction(jumps[i-1], jumps[i])
+		for p := jumps[i-1]; p != jumps[i]; {
+			p = p.Add(d)
+			path = append(path, p)
+		}
+	}
+	return path
+}
@@ -0,0 +1,13 @@
+package navmesh
+
+import "errors"
+
+var (
+	ErrInvalidIndexCount  = errors.New("The index list length must be a multiple of 3.")
+	ErrIndexOutOfRange    = errors.New("The index list references a vertex outside the vertex list.")
+	ErrDegenerateTriangle = errors.New("The index list contains a triangle without area.")
+	ErrNonManifoldEdge    = errors.New("The index list contains an edge shared by more than two triangles.")
+	ErrPointOutsideMesh   = errors.New("The point does not lie on the navigation mesh.")
+	ErrInvalidCorridor    = errors.New("The corridor does not describe a sequence of adjacent triangles.")
+	ErrNoPath             = errors.New("There is no path between the points.")
+)
@@ -0,0 +1,148 @@
+// Package navmesh provides navigation meshes, for finding paths across the walkable triangles of a triangulation
+package navmesh
+
+import (
+	"github.com/mindera-gaming/go-math/bounds"
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	"github.com/mindera-gaming/go-math/spatial"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// NoTriangle is the index of a missing triangle, such as the neighbour across a boundary edge
+const NoTriangle = -1
+
+// Mesh represents a navigation mesh, a set of walkable triangles connected through the edges they share.
+// The edges are shared by the position of their vertices, so the triangles may come from separate vertex lists
+type Mesh struct {
+	vertices   []vector.Vector2
+	triangles  [][3]int // vertex indices of each triangle, in counter-clockwise order
+	neighbours [][3]int // triangle across each edge, from the vertex k to the vertex k+1
+	centroids  []vector.Vector2
+	boundary   []bool // whether each vertex lies on a boundary edge
+	index      *spatial.AABBTree
+}
+
+// NewMesh builds the navigation mesh of the triangles, given as the indices of their vertices in any winding order,
+// such as the ones returned by earclipping.Triangulate.
+// Returns an error if the indices do not describe triangles with an area, or if an edge is shared by more than two
+// triangles
+func NewMesh(vertices []vector.Vector2, triangles []int) (*Mesh, error) {
+	if len(triangles)%3 != 0 {
+		return nil, ErrInvalidIndexCount
+	}
+	for _, i := range triangles {
+		if i < 0 || i >= len(vertices) {
+			return nil, ErrIndexOutOfRange
+		}
+	}
+
+	// the vertices at the same position are merged into the first one
+	m := &Mesh{vertices: append([]vector.Vector2{}, vertices...), index: spatial.NewAABBTree(0)}
+	first := make(map[vector.Vector2]int, len(vertices))
+	canonical := make([]int, len(vertices))
+	for i, v := range vertices {
+		if j, ok := first[v]; ok {
+			canonical[i] = j
+		} else {
+			first[v] = i
+			canonical[i] = i
+		}
+	}
+
+	for i := 0; i < len(triangles); i += 3 {
+		t := [3]int{canonical[triangles[i]], canonical[triangles[i+1]], canonical[triangles[i+2]]}
+		a, b, c := vertices[t[0]], vertices[t[1]], vertices[t[2]]
+		switch orientation := predicates.Orient2D(a, b, c); {
+		case orientation == 0:
+			return nil, ErrDegenerateTriangle
+		case orientation < 0:
+			t[1], t[2] = t[2], t[1]
+		}
+		m.triangles = append(m.triangles, t)
+		m.centroids = append(m.centroids, a.Add(b).Add(c).Div(3))
+		m.index.Insert(bounds.NewAABBFromPoints([]vector.Vector2{a, b, c}))
+	}
+
+	// each edge is matched with the same edge in the opposite direction
+	edges := make(map[[2]int][2]int, 3*len(m.triangles))
+	m.neighbours = make([][3]int, len(m.triangles))
+	for i, t := range m.triangles {
+		m.neighbours[i] = [3]int{NoTriangle, NoTriangle, NoTriangle}
+		for k := range t {
+			key := [2]int{t[k], t[(k+1)%3]}
+			if _, ok := edges[key]; ok {
+				return nil, ErrNonManifoldEdge
+			}
+			edges[key] = [2]int{i, k}
+		}
+	}
+	m.boundary = make([]bool, len(vertices))
+	for key, edge := range edges {
+		if twin, ok := edges[[2]int{key[1], key[0]}]; ok {
+			m.neighbours[edge[0]][edge[1]] = twin[0]
+		} else {
+			m.boundary[key[0]] = true
+			m.boundary[key[1]] = true
+		}
+	}
+	return m, nil
+}
+
+// Len returns the number of triangles
+func (m *Mesh) Len() int {
+	return len(m.triangles)
+}
+
+// Triangle returns the vertices of the triangle with the index, in counter-clockwise order
+func (m *Mesh) Triangle(i int) (a, b, c vector.Vector2) {
+	t := m.triangles[i]
+	return m.vertices[t[0]], m.vertices[t[1]], m.vertices[t[2]]
+}
+
+// Neighbours returns the triangles across each edge of the triangle with the index, from its vertex k to its
+// vertex k+1, or NoTriangle across the boundary edges
+func (m *Mesh) Neighbours(i int) [3]int {
+	return m.neighbours[i]
+}
+
+// Centroid returns the centroid of the triangle with the index
+func (m *Mesh) Centroid(i int) vector.Vector2 {
+	return m.centroids[i]
+}
+
+// Locate returns the index of the triangle holding the point, or false if the point lies outside the mesh.
+// A point on an edge shared by several triangles is located in the one with the lowest index
+func (m *Mesh) Locate(p vector.Vector2) (int, bool) {
+	for _, i := range m.index.Query(bounds.NewAABB(p, p)) {
+		a, b, c := m.Triangle(i)
+		if predicates.Orient2D(a, b, p) >= 0 && predicates.Orient2D(b, c, p) >= 0 && predicates.Orient2D(c, a, p) >= 0 {
+			return i, true
+		}
+	}
+	return NoTriangle, false
+}
+
+// portal returns the edge the triangle shares with its neighbour across its edge k, with the left and right
+// endpoints as seen when crossing it. The endpoints on the boundary of the mesh are moved towards each other by the
+// radius. Returns false if the edge is narrower than that
+func (m *Mesh) portal(triangle, k int, radius float64) (left, right vector.Vector2, ok bool) {
+	// the triangle lies on the left of its counter-clockwise edges, so the end of the edge is on the left when crossing
+	t := m.triangles[triangle]
+	a, b := t[k], t[(k+1)%3]
+	left, right = m.vertices[b], m.vertices[a]
+	if radius <= 0 {
+		return left, right, true
+	}
+
+	width := right.Distance(left)
+	direction := right.To(left).Div(width)
+	if m.boundary[a] {
+		right = right.Add(direction.Mul(radius))
+		width -= radius
+	}
+	if m.boundary[b] {
+		left = left.Sub(direction.Mul(radius))
+		width -= radius
+	}
+	return left, right, width >= 0
+}
@@ -0,0 +1,236 @@
+package navmesh
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/mindera-gaming/go-math/geometry/predicates"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Waypoints defines an enumerator representing the points through which the path search measures the distance
+// travelled across the triangles
+type Waypoints byte
+
+const (
+	// Centroids measures the distance between the centroids of the triangles
+	Centroids Waypoints = iota
+	// PortalMidpoints measures the distance between the midpoints of the edges crossed, which follows the final path
+	// more closely across large triangles
+	PortalMidpoints
+)
+
+// PathOptions is the structure that defines the path search options
+type PathOptions struct {
+	Waypoints   Waypoints // points through which the distance is measured, Centroids by default
+	AgentRadius float64   // distance kept from the corners of the boundary, which also closes narrower passages
+}
+
+// FindPath finds a path between the points across the mesh, using the A* algorithm to find the corridor of triangles
+// and the funnel algorithm to pull it into the shortest path through that corridor.
+// Returns the corners of the path, from the start to the goal, or an error if either point lies outside the mesh or
+// if the goal cannot be reached
+func (m *Mesh) FindPath(start, goal vector.Vector2, options PathOptions) ([]vector.Vector2, error) {
+	corridor, err := m.FindCorridor(start, goal, options)
+	if err != nil {
+		return nil, err
+	}
+	return m.StringPull(start, goal, corridor, options.AgentRadius)
+}
+
+// FindCorridor finds the sequence of adjacent triangles leading from the start to the goal, using the A* algorithm
+// over the triangles. The edges narrower than the agent, once the radius is kept from their corners on the boundary,
+// are not crossed.
+// Returns the indices of the triangles, from the one holding the start to the one holding the goal, or an error if
+// either point lies outside the mesh or if the goal cannot be reached
+func (m *Mesh) FindCorridor(start, goal vector.Vector2, options PathOptions) ([]int, error) {
+	from, ok := m.Locate(start)
+	if !ok {
+		return nil, ErrPointOutsideMesh
+	}
+	to, ok := m.Locate(goal)
+	if !ok {
+		return nil, ErrPointOutsideMesh
+	}
+
+	// each triangle is reached at a waypoint, from which the distance to the next waypoint is measured
+	cost := make([]float64, len(m.triangles))
+	waypoint := make([]vector.Vector2, len(m.triangles))
+	parent := make([]int, len(m.triangles))
+	closed := make([]bool, len(m.triangles))
+	for i := range cost {
+		cost[i] = math.Inf(1)
+		parent[i] = NoTriangle
+	}
+	cost[from] = 0
+	waypoint[from] = start
+
+	queue := &nodeQueue{{triangle: from, priority: start.Distance(goal)}}
+	for queue.Len() != 0 {
+		t := heap.Pop(queue).(node).triangle
+		if closed[t] {
+			continue
+		}
+		closed[t] = true
+		if t == to {
+			break
+		}
+
+		for k, n := range m.neighbours[t] {
+			if n == NoTriangle || closed[n] {
+				continue
+			}
+			left, right, ok := m.portal(t, k, options.AgentRadius)
+			if !ok {
+				continue
+			}
+
+			p := m.centroids[n]
+			if options.Waypoints == PortalMidpoints {
+				p = vector.Lerp(left, right, 0.5)
+			} else if n == to {
+				p = goal
+			}
+			c := cost[t] + waypoint[t].Distance(p)
+			if n == to {
+				c += p.Distance(goal)
+				p = goal
+			}
+			if c < cost[n] {
+				cost[n] = c
+				waypoint[n] = p
+				parent[n] = t
+				heap.Push(queue, node{triangle: n, priority: c + p.Distance(goal)})
+			}
+		}
+	}
+	if !closed[to] {
+		return nil, ErrNoPath
+	}
+
+	var corridor []int
+	for t := to; t != NoTriangle; t = parent[t] {
+		corridor = append(corridor, t)
+	}
+	for i, j := 0, len(corridor)-1; i < j; i, j = i+1, j-1 {
+		corridor[i], corridor[j] = corridor[j], corridor[i]
+	}
+	return corridor, nil
+}
+
+// StringPull finds the shortest path from the start to the goal through the corridor of adjacent triangles, using
+// the funnel algorithm. The path keeps the radius from the corners of the boundary it turns around.
+// Returns the corners of the path, from the start to the goal, or an error if consecutive triangles of the corridor
+// are not adjacent
+func (m *Mesh) StringPull(start, goal vector.Vector2, corridor []int, radius float64) ([]vector.Vector2, error) {
+	// the portals are the edges crossed between the triangles, preceded by the start and followed by the goal.
+	// The portals before the last one holding the start, and after the first one holding the goal, are skipped,
+	// as the funnel cannot open from a point lying on a portal
+	lefts := []vector.Vector2{start}
+	rights := []vector.Vector2{start}
+	for i := 1; i < len(corridor); i++ {
+		k := m.edgeTo(corridor[i-1], corridor[i])
+		if k < 0 {
+			return nil, ErrInvalidCorridor
+		}
+		a, b, _ := m.portal(corridor[i-1], k, 0)
+		switch {
+		case isOnSegment(start, a, b):
+			lefts, rights = lefts[:1], rights[:1]
+		case isOnSegment(goal, a, b):
+			i = len(corridor)
+		default:
+			left, right, _ := m.portal(corridor[i-1], k, radius)
+			lefts = append(lefts, left)
+			rights = append(rights, right)
+		}
+	}
+	lefts = append(lefts, goal)
+	rights = append(rights, goal)
+
+	// the funnel spans from the apex to its left and right sides, and is narrowed by every portal until one of its
+	// sides crosses over the other one, which turns that side into a corner of the path and the new apex
+	path := []vector.Vector2{start}
+	apex, left, right := start, start, start
+	apexIndex, leftIndex, rightIndex := 0, 0, 0
+	for i := 1; i < len(lefts); i++ {
+		if predicates.Orient2D(apex, right, rights[i]) >= 0 {
+			if apex == right || predicates.Orient2D(apex, left, rights[i]) < 0 {
+				right, rightIndex = rights[i], i
+			} else {
+				path = append(path, left)
+				apex, apexIndex = left, leftIndex
+				right, rightIndex = apex, apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+
+		if predicates.Orient2D(apex, left, lefts[i]) <= 0 {
+			if apex == left || predicates.Orient2D(apex, right, lefts[i]) > 0 {
+				left, leftIndex = lefts[i], i
+			} else {
+				path = append(path, right)
+				apex, apexIndex = right, rightIndex
+				left, leftIndex = apex, apexIndex
+				i = apexIndex
+				continue
+			}
+		}
+	}
+
+	if path[len(path)-1] != goal {
+		path = append(path, goal)
+	}
+	return path, nil
+}
+
+// isOnSegment determines whether the point p lies on the segment from a to b
+func isOnSegment(p, a, b vector.Vector2) bool {
+	return predicates.Orient2D(a, b, p) == 0 && p.To(a).Dot(p.To(b)) <= 0
+}
+
+// edgeTo returns the edge of the triangle shared with its neighbour, or -1 if they are not adjacent
+func (m *Mesh) edgeTo(triangle, neighbour int) int {
+	for k, n := range m.neighbours[triangle] {
+		if n == neighbour {
+			return k
+		}
+	}
+	return -1
+}
+
+// node is a triangle queued by the A* algorithm
+type node struct {
+	triangle int
+	priority float64 // cost to reach the triangle plus the estimated cost to the goal
+}
+
+// nodeQueue is a priority queue of triangles, ordered by their priority and then by their index
+type nodeQueue []node
+
+func (q nodeQueue) Len() int {
+	return len(q)
+}
+
+func (q nodeQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority < q[j].priority
+	}
+	return q[i].triangle < q[j].triangle
+}
+
+func (q nodeQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *nodeQueue) Push(x interface{}) {
+	*q = append(*q, x.(node))
+}
+
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,67 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Capsule represents a 2D capsule, the set of points within its radius of the segment between its endpoints
+type Capsule struct {
+	A      vector.Vector2
+	B      vector.Vector2
+	Radius float64
+}
+
+// NewCapsule creates a capsule from the endpoints of its inner segment and its radius
+func NewCapsule(a, b vector.Vector2, radius float64) Capsule {
+	return Capsule{A: a, B: b, Radius: math.Abs(radius)}
+}
+
+// Segment returns the inner segment of the capsule
+func (c Capsule) Segment() Segment {
+	return Segment{A: c.A, B: c.B}
+}
+
+// Area returns the area of the capsule
+func (c Capsule) Area() float64 {
+	return math.Pi*c.Radius*c.Radius + 2*c.Radius*c.A.Distance(c.B)
+}
+
+// Perimeter returns the perimeter of the capsule
+func (c Capsule) Perimeter() float64 {
+	return 2*math.Pi*c.Radius + 2*c.A.Distance(c.B)
+}
+
+// Bounds returns the smallest axis-aligned box containing the capsule
+func (c Capsule) Bounds() bounds.AABB {
+	return bounds.NewAABB(c.A, c.B).Expand(c.Radius)
+}
+
+// ContainsPoint checks if the point lies inside the capsule or on its boundary
+func (c Capsule) ContainsPoint(p vector.Vector2) bool {
+	return c.Segment().ClosestPoint(p).DistanceSqr(p) <= c.Radius*c.Radius
+}
+
+// ClosestPoint returns the point of the capsule closest to the point p.
+// Returns the point itself if it lies inside the capsule
+func (c Capsule) ClosestPoint(p vector.Vector2) vector.Vector2 {
+	return Circle{Center: c.Segment().ClosestPoint(p), Radius: c.Radius}.ClosestPoint(p)
+}
+
+// Distance returns the distance between the point p and the capsule, or 0 if it lies inside the capsule
+func (c Capsule) Distance(p vector.Vector2) float64 {
+	return math.Max(0, c.Segment().Distance(p)-c.Radius)
+}
+
+// OverlapsCircle checks if the capsule and the circle share at least one point, touching boundaries included
+func (c Capsule) OverlapsCircle(circle Circle) bool {
+	radius := c.Radius + circle.Radius
+	return c.Segment().ClosestPoint(circle.Center).DistanceSqr(circle.Center) <= radius*radius
+}
+
+// OverlapsCapsule checks if both capsules share at least one point, touching boundaries included
+func (c Capsule) OverlapsCapsule(other Capsule) bool {
+	return c.Segment().DistanceToSegment(other.Segment()) <= c.Radius+other.Radius
+}
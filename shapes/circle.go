@@ -0,0 +1,80 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Circle represents a 2D circle, given by its center and its radius
+type Circle struct {
+	Center vector.Vector2
+	Radius float64
+}
+
+// NewCircle creates a circle from its center and its radius
+func NewCircle(center vector.Vector2, radius float64) Circle {
+	return Circle{Center: center, Radius: math.Abs(radius)}
+}
+
+// Area returns the area of the circle
+func (c Circle) Area() float64 {
+	return math.Pi * c.Radius * c.Radius
+}
+
+// Perimeter returns the perimeter of the circle
+func (c Circle) Perimeter() float64 {
+	return 2 * math.Pi * c.Radius
+}
+
+// Bounds returns the smallest axis-aligned box containing the circle
+func (c Circle) Bounds() bounds.AABB {
+	return bounds.NewAABBFromCenter(c.Center, vector.Vector2{X: c.Radius, Y: c.Radius})
+}
+
+// ContainsPoint checks if the point lies inside the circle or on its boundary
+func (c Circle) ContainsPoint(p vector.Vector2) bool {
+	return c.Center.DistanceSqr(p) <= c.Radius*c.Radius
+}
+
+// ClosestPoint returns the point of the circle closest to the point p.
+// Returns the point itself if it lies inside the circle
+func (c Circle) ClosestPoint(p vector.Vector2) vector.Vector2 {
+	if c.ContainsPoint(p) {
+		return p
+	}
+	return c.Center.Add(c.Center.To(p).Normalized().Mul(c.Radius))
+}
+
+// Distance returns the distance between the point p and the circle, or 0 if it lies inside the circle
+func (c Circle) Distance(p vector.Vector2) float64 {
+	return math.Max(0, c.Center.Distance(p)-c.Radius)
+}
+
+// Overlaps checks if both circles share at least one point, touching boundaries included
+func (c Circle) Overlaps(other Circle) bool {
+	radius := c.Radius + other.Radius
+	return c.Center.DistanceSqr(other.Center) <= radius*radius
+}
+
+// IntersectCircle intersects the boundaries of both circles.
+// Returns no points if they do not meet or if the circles are the same, one point if they touch each other,
+// or two points otherwise, which lie to the right and to the left of the line between the centers respectively
+func (c Circle) IntersectCircle(other Circle) []vector.Vector2 {
+	d := c.Center.To(other.Center)
+	distance := d.Magnitude()
+	if distance == 0 || distance > c.Radius+other.Radius || distance < math.Abs(c.Radius-other.Radius) {
+		return nil
+	}
+
+	// the intersections lie on the chord perpendicular to the line between the centers
+	along := (distance*distance + c.Radius*c.Radius - other.Radius*other.Radius) / (2 * distance)
+	across := math.Sqrt(math.Max(0, c.Radius*c.Radius-along*along))
+	u := d.Div(distance)
+	middle := c.Center.Add(u.Mul(along))
+	if across == 0 {
+		return []vector.Vector2{middle}
+	}
+	return []vector.Vector2{middle.Add(u.Right().Mul(across)), middle.Add(u.Left().Mul(across))}
+}
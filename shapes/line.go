@@ -0,0 +1,112 @@
+// Package shapes provides structs for handling 2D primitive shapes, along with closest point, distance and
+// intersection queries between them
+package shapes
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// parameterTolerance is the rounding error allowed on the parameters of intersections, which are relative to the
+// length of the shapes, so that segments sharing an endpoint are found to meet
+const parameterTolerance = 1e-9
+
+// Line represents an infinite 2D line, given by a point on it and its direction
+type Line struct {
+	Point     vector.Vector2
+	Direction vector.Vector2
+}
+
+// NewLine creates a line passing through both points, directed from the first to the second
+func NewLine(a, b vector.Vector2) Line {
+	return Line{Point: a, Direction: a.To(b)}
+}
+
+// PointAt returns the point of the line at the parameter t, a multiple of its direction
+func (l Line) PointAt(t float64) vector.Vector2 {
+	return l.Point.Add(l.Direction.Mul(t))
+}
+
+// ClosestParameter returns the parameter of the point of the line closest to the point p
+func (l Line) ClosestParameter(p vector.Vector2) float64 {
+	return projectParameter(l.Point, l.Direction, p)
+}
+
+// ClosestPoint returns the point of the line closest to the point p
+func (l Line) ClosestPoint(p vector.Vector2) vector.Vector2 {
+	return l.PointAt(l.ClosestParameter(p))
+}
+
+// Distance returns the distance between the point p and the line
+func (l Line) Distance(p vector.Vector2) float64 {
+	return l.ClosestPoint(p).Distance(p)
+}
+
+// Side returns a positive value if the point p lies to the left of the line, looking along its direction,
+// a negative value if it lies to the right, or 0 if it lies on the line
+func (l Line) Side(p vector.Vector2) float64 {
+	return l.Direction.Cross(l.Point.To(p))
+}
+
+// IntersectLine intersects both lines.
+// Returns the parameters of the intersection along this line and the other one, or false if they are parallel
+func (l Line) IntersectLine(other Line) (t, u float64, ok bool) {
+	return intersectLines(l.Point, l.Direction, other.Point, other.Direction)
+}
+
+// IntersectCircle intersects the line with the circle.
+// Returns the parameters where the line enters and leaves the circle, or false if it misses the circle
+func (l Line) IntersectCircle(c Circle) (enter, exit float64, ok bool) {
+	return intersectLineCircle(l.Point, l.Direction, c)
+}
+
+// projectParameter returns the parameter, a multiple of the direction, of the projection of the point p onto
+// the line through the origin along the direction. A zero direction yields 0
+func projectParameter(origin, direction, p vector.Vector2) float64 {
+	lengthSqr := direction.MagnitudeSqr()
+	if lengthSqr == 0 {
+		return 0
+	}
+	return origin.To(p).Dot(direction) / lengthSqr
+}
+
+// intersectLines returns the parameters of the intersection of the lines through p along d and through q along e.
+// Returns false if the lines are parallel
+func intersectLines(p, d, q, e vector.Vector2) (t, u float64, ok bool) {
+	denominator := d.Cross(e)
+	if denominator == 0 {
+		return 0, 0, false
+	}
+	w := p.To(q)
+	return w.Cross(e) / denominator, w.Cross(d) / denominator, true
+}
+
+// clampParameter clamps the parameter to the range [min, max].
+// Returns false if it lies outside that range by more than the tolerance
+func clampParameter(t, min, max float64) (float64, bool) {
+	if t < min-parameterTolerance || t > max+parameterTolerance {
+		return 0, false
+	}
+	return mathf.Clamp(t, min, max), true
+}
+
+// intersectLineCircle returns the parameters where the line through the origin along the direction enters and
+// leaves the circle, solving |origin + t·direction - center|² = radius². Returns false if it misses the circle
+func intersectLineCircle(origin, direction vector.Vector2, c Circle) (enter, exit float64, ok bool) {
+	w := c.Center.To(origin)
+	a := direction.MagnitudeSqr()
+	b := 2 * direction.Dot(w)
+	k := w.MagnitudeSqr() - c.Radius*c.Radius
+	if a == 0 {
+		// a zero direction only reaches the origin
+		return 0, 0, k <= 0
+	}
+	if b*b-4*a*k < 0 {
+		return 0, 0, false
+	}
+
+	exit, enter = mathf.QuadraticFormula(a, b, k)
+	return math.Min(enter, exit), math.Max(enter, exit), true
+}
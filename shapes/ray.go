@@ -0,0 +1,94 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Ray represents a 2D half-line, given by its origin and its direction
+type Ray struct {
+	Origin    vector.Vector2
+	Direction vector.Vector2
+}
+
+// NewRay creates a ray from its origin and its direction, which does not need to be normalized
+func NewRay(origin, direction vector.Vector2) Ray {
+	return Ray{Origin: origin, Direction: direction}
+}
+
+// PointAt returns the point of the ray at the parameter t, a multiple of its direction
+func (r Ray) PointAt(t float64) vector.Vector2 {
+	return r.Origin.Add(r.Direction.Mul(t))
+}
+
+// ClosestParameter returns the parameter of the point of the ray closest to the point p, which is never negative
+func (r Ray) ClosestParameter(p vector.Vector2) float64 {
+	return math.Max(0, projectParameter(r.Origin, r.Direction, p))
+}
+
+// ClosestPoint returns the point of the ray closest to the point p
+func (r Ray) ClosestPoint(p vector.Vector2) vector.Vector2 {
+	return r.PointAt(r.ClosestParameter(p))
+}
+
+// Distance returns the distance between the point p and the ray
+func (r Ray) Distance(p vector.Vector2) float64 {
+	return r.ClosestPoint(p).Distance(p)
+}
+
+// IntersectLine intersects the ray with the line.
+// Returns the parameters of the intersection along the ray and the line, or false if they do not meet
+func (r Ray) IntersectLine(l Line) (t, u float64, ok bool) {
+	t, u, ok = intersectLines(r.Origin, r.Direction, l.Point, l.Direction)
+	if !ok || t < 0 {
+		return 0, 0, false
+	}
+	return t, u, true
+}
+
+// IntersectSegment intersects the ray with the segment.
+// Returns the parameters of the intersection along the ray and the segment, from 0 at its start to 1 at its end,
+// or false if they do not meet. A segment lying along the ray is hit at its closest point to the origin
+func (r Ray) IntersectSegment(s Segment) (t, u float64, ok bool) {
+	t, u, ok = intersectLines(r.Origin, r.Direction, s.A, s.A.To(s.B))
+	if ok {
+		var inT, inU bool
+		t, inT = clampParameter(t, 0, math.Inf(1))
+		u, inU = clampParameter(u, 0, 1)
+		if !inT || !inU {
+			return 0, 0, false
+		}
+		return t, u, true
+	}
+
+	// a parallel segment is only hit if it lies along the ray
+	if r.Direction.Cross(r.Origin.To(s.A)) != 0 || r.Direction.IsZero() {
+		return 0, 0, false
+	}
+	ta := projectParameter(r.Origin, r.Direction, s.A)
+	tb := projectParameter(r.Origin, r.Direction, s.B)
+	if ta < 0 && tb < 0 {
+		return 0, 0, false
+	}
+	switch {
+	case ta <= tb && ta >= 0:
+		return ta, 0, true
+	case tb <= ta && tb >= 0:
+		return tb, 1, true
+	}
+	// the origin lies within the segment
+	return 0, mathf.Clamp(projectParameter(s.A, s.A.To(s.B), r.Origin), 0, 1), true
+}
+
+// IntersectCircle intersects the ray with the circle.
+// Returns the parameters where the ray enters and leaves the circle, where the entry is 0 if the origin lies inside
+// the circle, or false if it misses the circle
+func (r Ray) IntersectCircle(c Circle) (enter, exit float64, ok bool) {
+	enter, exit, ok = intersectLineCircle(r.Origin, r.Direction, c)
+	if !ok || exit < 0 {
+		return 0, 0, false
+	}
+	return math.Max(enter, 0), exit, true
+}
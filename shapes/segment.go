@@ -0,0 +1,151 @@
+package shapes
+
+import (
+	"math"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	"github.com/mindera-gaming/go-math/mathf"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Segment represents a 2D line segment, given by its endpoints
+type Segment struct {
+	A vector.Vector2
+	B vector.Vector2
+}
+
+// NewSegment creates a segment from its endpoints
+func NewSegment(a, b vector.Vector2) Segment {
+	return Segment{A: a, B: b}
+}
+
+// Direction returns the vector from the start to the end of the segment
+func (s Segment) Direction() vector.Vector2 {
+	return s.A.To(s.B)
+}
+
+// Length returns the length of the segment
+func (s Segment) Length() float64 {
+	return s.A.Distance(s.B)
+}
+
+// Midpoint returns the point halfway between the endpoints of the segment
+func (s Segment) Midpoint() vector.Vector2 {
+	return vector.Lerp(s.A, s.B, 0.5)
+}
+
+// PointAt returns the point of the segment at the parameter t, from 0 at its start to 1 at its end.
+// The parameter t is not clamped
+func (s Segment) PointAt(t float64) vector.Vector2 {
+	return vector.LerpUnclamped(s.A, s.B, t)
+}
+
+// Bounds returns the smallest axis-aligned box containing the segment
+func (s Segment) Bounds() bounds.AABB {
+	return bounds.NewAABB(s.A, s.B)
+}
+
+// ClosestParameter returns the parameter, in the range [0, 1], of the point of the segment closest to the point p
+func (s Segment) ClosestParameter(p vector.Vector2) float64 {
+	return mathf.Clamp(projectParameter(s.A, s.Direction(), p), 0, 1)
+}
+
+// ClosestPoint returns the point of the segment closest to the point p
+func (s Segment) ClosestPoint(p vector.Vector2) vector.Vector2 {
+	return s.PointAt(s.ClosestParameter(p))
+}
+
+// Distance returns the distance between the point p and the segment
+func (s Segment) Distance(p vector.Vector2) float64 {
+	return s.ClosestPoint(p).Distance(p)
+}
+
+// ClosestParameters returns the parameters of the closest points between both segments, along this segment and
+// the other one. Overlapping parallel segments yield one of the pairs of closest points
+func (s Segment) ClosestParameters(other Segment) (t, u float64) {
+	d, e := s.Direction(), other.Direction()
+	w := other.A.To(s.A)
+	a, b, c := d.Dot(d), d.Dot(e), e.Dot(e)
+	f, g := e.Dot(w), d.Dot(w)
+
+	switch {
+	case a == 0 && c == 0:
+		return 0, 0
+	case a == 0:
+		return 0, mathf.Clamp(f/c, 0, 1)
+	case c == 0:
+		return mathf.Clamp(-g/a, 0, 1), 0
+	}
+
+	// the closest points of the infinite lines are clamped to this segment, then to the other one and back
+	if denominator := a*c - b*b; denominator > 0 {
+		t = mathf.Clamp((b*f-c*g)/denominator, 0, 1)
+	}
+	u = (b*t + f) / c
+	if u < 0 {
+		u = 0
+		t = mathf.Clamp(-g/a, 0, 1)
+	} else if u > 1 {
+		u = 1
+		t = mathf.Clamp((b-g)/a, 0, 1)
+	}
+	return
+}
+
+// DistanceToSegment returns the distance between the closest points of both segments, or 0 if they intersect
+func (s Segment) DistanceToSegment(other Segment) float64 {
+	t, u := s.ClosestParameters(other)
+	return s.PointAt(t).Distance(other.PointAt(u))
+}
+
+// IntersectSegment intersects both segments.
+// Returns the parameters of the intersection along this segment and the other one, from 0 at their start to 1 at
+// their end, or false if they do not meet. Overlapping collinear segments meet at the first point of this segment
+// lying on the other one
+func (s Segment) IntersectSegment(other Segment) (t, u float64, ok bool) {
+	d, e := s.Direction(), other.Direction()
+	t, u, ok = intersectLines(s.A, d, other.A, e)
+	if ok {
+		var inT, inU bool
+		t, inT = clampParameter(t, 0, 1)
+		u, inU = clampParameter(u, 0, 1)
+		if !inT || !inU {
+			return 0, 0, false
+		}
+		return t, u, true
+	}
+
+	// parallel segments only meet if they are collinear and overlap
+	if d.Cross(s.A.To(other.A)) != 0 || e.Cross(other.A.To(s.A)) != 0 {
+		return 0, 0, false
+	}
+	if d.IsZero() {
+		if e.IsZero() {
+			if s.A == other.A {
+				return 0, 0, true
+			}
+			return 0, 0, false
+		}
+		u, ok = clampParameter(projectParameter(other.A, e, s.A), 0, 1)
+		return 0, u, ok
+	}
+
+	ta := projectParameter(s.A, d, other.A)
+	tb := projectParameter(s.A, d, other.B)
+	t = math.Max(0, math.Min(ta, tb))
+	if t > 1 || t > math.Max(ta, tb) {
+		return 0, 0, false
+	}
+	return t, mathf.Clamp(projectParameter(other.A, e, s.PointAt(t)), 0, 1), true
+}
+
+// IntersectCircle intersects the segment with the circle.
+// Returns the parameters where the segment enters and leaves the circle, clamped to the segment if it starts or
+// ends inside the circle, or false if it misses the circle
+func (s Segment) IntersectCircle(c Circle) (enter, exit float64, ok bool) {
+	enter, exit, ok = intersectLineCircle(s.A, s.Direction(), c)
+	if !ok || exit < 0 || enter > 1 {
+		return 0, 0, false
+	}
+	return math.Max(enter, 0), math.Min(exit, 1), true
+}
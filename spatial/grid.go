@@ -0,0 +1,261 @@
+package spatial
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	"github.com/mindera-gaming/go-math/mathi"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// HashGrid represents a uniform grid of square cells, which are stored in a hash map so that the grid is unbounded.
+// Every item is listed in each cell its box overlaps, so it works best when the items are about the size of a cell
+type HashGrid struct {
+	registry
+	cellSize float64
+	cells    map[[2]int64][]int
+	min      [2]int64 // lowest cell coordinates ever used
+	max      [2]int64 // highest cell coordinates ever used
+}
+
+// NewHashGrid creates an empty grid whose cells have the given size
+func NewHashGrid(cellSize float64) *HashGrid {
+	return &HashGrid{
+		cellSize: cellSize,
+		cells:    make(map[[2]int64][]int),
+		min:      [2]int64{math.MaxInt64, math.MaxInt64},
+		max:      [2]int64{math.MinInt64, math.MinInt64},
+	}
+}
+
+// Insert adds an item bounded by the box. Returns the ID of the item
+func (g *HashGrid) Insert(box bounds.AABB) int {
+	id := g.add(box)
+	g.addCells(id, box)
+	return id
+}
+
+// Update changes the box of the item with the ID. The cells listing it only change if the box moves across cells
+func (g *HashGrid) Update(id int, box bounds.AABB) {
+	oldMin, oldMax := g.cellRange(g.boxes[id])
+	newMin, newMax := g.cellRange(box)
+	if oldMin != newMin || oldMax != newMax {
+		g.removeCells(id, g.boxes[id])
+		g.addCells(id, box)
+	}
+	g.boxes[id] = box
+}
+
+// Remove removes the item with the ID, which may be reused by later insertions
+func (g *HashGrid) Remove(id int) {
+	g.removeCells(id, g.boxes[id])
+	g.remove(id)
+}
+
+// Query returns the IDs of the items whose box overlaps the region, in increasing order
+func (g *HashGrid) Query(region bounds.AABB) []int {
+	var result []int
+	min, max := g.cellRange(region)
+	min, max = g.clampRange(min, max)
+	if min[0] > max[0] || min[1] > max[1] {
+		return nil
+	}
+	cells := float64(max[0]-min[0]+1) * float64(max[1]-min[1]+1)
+	if cells > float64(g.count) {
+		// scanning every item is cheaper than scanning the cells of a large region
+		for id, alive := range g.alive {
+			if alive && g.boxes[id].Overlaps(region) {
+				result = append(result, id)
+			}
+		}
+		return result
+	}
+
+	seen := make(map[int]bool)
+	for x := min[0]; x <= max[0]; x++ {
+		for y := min[1]; y <= max[1]; y++ {
+			for _, id := range g.cells[[2]int64{x, y}] {
+				if !seen[id] && g.boxes[id].Overlaps(region) {
+					result = append(result, id)
+				}
+				seen[id] = true
+			}
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+// Raycast returns the items whose box is hit by the ray before the maximum parameter, a multiple of its
+// direction, sorted by the parameter where the ray enters their box. The cells are walked along the ray
+func (g *HashGrid) Raycast(origin, direction vector.Vector2, max float64) []RayHit {
+	if g.count == 0 {
+		return nil
+	}
+
+	// the walk starts where the ray enters the cells ever used
+	extent := bounds.AABB{
+		Min: vector.Vector2{X: float64(g.min[0]) * g.cellSize, Y: float64(g.min[1]) * g.cellSize},
+		Max: vector.Vector2{X: float64(g.max[0]+1) * g.cellSize, Y: float64(g.max[1]+1) * g.cellSize},
+	}
+	enter, exit, ok := extent.IntersectRay(origin, direction)
+	exit = math.Min(exit, max)
+	if !ok || enter > exit {
+		return nil
+	}
+
+	start := origin.Add(direction.Mul(enter))
+	cell := [2]int64{g.cell(start.X), g.cell(start.Y)}
+	cell, _ = g.clampRange(cell, cell)
+	position := [2]float64{origin.X, origin.Y}
+	delta := [2]float64{direction.X, direction.Y}
+	var step [2]int64
+	var next, increment [2]float64
+	for axis := range delta {
+		next[axis], increment[axis] = math.Inf(1), math.Inf(1)
+		if delta[axis] > 0 {
+			step[axis] = 1
+			next[axis] = (float64(cell[axis]+1)*g.cellSize - position[axis]) / delta[axis]
+			increment[axis] = g.cellSize / delta[axis]
+		} else if delta[axis] < 0 {
+			step[axis] = -1
+			next[axis] = (float64(cell[axis])*g.cellSize - position[axis]) / delta[axis]
+			increment[axis] = -g.cellSize / delta[axis]
+		}
+	}
+
+	var hits []RayHit
+	seen := make(map[int]bool)
+	for t := enter; t <= exit; {
+		for _, id := range g.cells[cell] {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if hit, _, ok := g.boxes[id].IntersectRay(origin, direction); ok && hit <= max {
+				hits = append(hits, RayHit{ID: id, Parameter: hit})
+			}
+		}
+
+		axis := 0
+		if next[1] < next[0] {
+			axis = 1
+		}
+		if math.IsInf(next[axis], 1) {
+			// a ray without direction never leaves the cell of its origin
+			break
+		}
+		t = next[axis]
+		next[axis] += increment[axis]
+		cell[axis] += step[axis]
+		if cell[axis] < g.min[axis] || cell[axis] > g.max[axis] {
+			break
+		}
+	}
+	return sortHits(hits)
+}
+
+// Nearest returns the ID of the item whose box is closest to the point, or false if there are no items.
+// The rings of cells around the point are searched until no closer item can be found
+func (g *HashGrid) Nearest(p vector.Vector2) (int, bool) {
+	if g.count == 0 {
+		return 0, false
+	}
+
+	center := [2]int64{g.cell(p.X), g.cell(p.Y)}
+	first := int64(0)
+	last := int64(0)
+	for axis := range center {
+		first = mathi.Max(first, mathi.Max(g.min[axis]-center[axis], center[axis]-g.max[axis]))
+		last = mathi.Max(last, mathi.Max(center[axis]-g.min[axis], g.max[axis]-center[axis]))
+	}
+
+	best, found := 0, false
+	bestDistance := 0.0
+	for ring := first; ring <= last; ring++ {
+		// every item in the ring lies at least ring - 1 cells away from the point
+		if found && bestDistance < float64(ring-1)*g.cellSize {
+			break
+		}
+		if 8*ring+1 > int64(g.count) {
+			return g.nearest(p)
+		}
+
+		for x := center[0] - ring; x <= center[0]+ring; x++ {
+			for y := center[1] - ring; y <= center[1]+ring; y++ {
+				if x != center[0]-ring && x != center[0]+ring && y != center[1]-ring && y != center[1]+ring {
+					continue
+				}
+				for _, id := range g.cells[[2]int64{x, y}] {
+					if distance := g.boxes[id].Distance(p); !found || distance < bestDistance || (distance == bestDistance && id < best) {
+						best, bestDistance, found = id, distance, true
+					}
+				}
+			}
+		}
+	}
+	return best, found
+}
+
+// Pairs returns every pair of IDs of items whose boxes overlap, with the lowest ID first, in increasing order
+func (g *HashGrid) Pairs() [][2]int {
+	return findPairs(&g.registry, g.Query)
+}
+
+// cell returns the coordinate of the cell holding the coordinate of a point
+func (g *HashGrid) cell(value float64) int64 {
+	return int64(math.Floor(value / g.cellSize))
+}
+
+// cellRange returns the coordinates of the lowest and highest cells overlapped by the box
+func (g *HashGrid) cellRange(box bounds.AABB) (min, max [2]int64) {
+	return [2]int64{g.cell(box.Min.X), g.cell(box.Min.Y)}, [2]int64{g.cell(box.Max.X), g.cell(box.Max.Y)}
+}
+
+// clampRange restricts the range of cells to the cells ever used
+func (g *HashGrid) clampRange(min, max [2]int64) ([2]int64, [2]int64) {
+	for axis := range min {
+		min[axis] = mathi.Max(min[axis], g.min[axis])
+		max[axis] = mathi.Min(max[axis], g.max[axis])
+	}
+	return min, max
+}
+
+// addCells lists the item in every cell its box overlaps
+func (g *HashGrid) addCells(id int, box bounds.AABB) {
+	min, max := g.cellRange(box)
+	for axis := range min {
+		g.min[axis] = mathi.Min(g.min[axis], min[axis])
+		g.max[axis] = mathi.Max(g.max[axis], max[axis])
+	}
+	for x := min[0]; x <= max[0]; x++ {
+		for y := min[1]; y <= max[1]; y++ {
+			key := [2]int64{x, y}
+			g.cells[key] = append(g.cells[key], id)
+		}
+	}
+}
+
+// removeCells removes the item from every cell its box overlaps, forgetting the cells left empty
+func (g *HashGrid) removeCells(id int, box bounds.AABB) {
+	min, max := g.cellRange(box)
+	for x := min[0]; x <= max[0]; x++ {
+		for y := min[1]; y <= max[1]; y++ {
+			key := [2]int64{x, y}
+			ids := g.cells[key]
+			for i, other := range ids {
+				if other == id {
+					ids[i] = ids[len(ids)-1]
+					ids = ids[:len(ids)-1]
+					break
+				}
+			}
+			if len(ids) == 0 {
+				delete(g.cells, key)
+			} else {
+				g.cells[key] = ids
+			}
+		}
+	}
+}
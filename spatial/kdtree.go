@@ -0,0 +1,226 @@
+package spatial
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// KDTree represents a static 2D k-d tree over a set of points, built once from all of them.
+// The tree is stored implicitly: every range of the reordered indices has its splitting point in the middle,
+// with the points before it on one side of the split and the points after it on the other side
+type KDTree struct {
+	points  []vector.Vector2
+	indices []int
+	axes    []byte // splitting axis of each position, 0 for X and 1 for Y
+}
+
+// NewKDTree builds a tree over a copy of the points.
+// Every range is split at the median of the axis along which its points spread the most
+func NewKDTree(points []vector.Vector2) *KDTree {
+	t := &KDTree{
+		points:  append([]vector.Vector2{}, points...),
+		indices: make([]int, len(points)),
+		axes:    make([]byte, len(points)),
+	}
+	for i := range t.indices {
+		t.indices[i] = i
+	}
+	t.build(0, len(points))
+	return t
+}
+
+// build splits the range [lo, hi) of the indices at its median
+func (t *KDTree) build(lo, hi int) {
+	if hi-lo <= 1 {
+		return
+	}
+
+	box := bounds.EmptyAABB()
+	for _, i := range t.indices[lo:hi] {
+		box = box.Encapsulate(t.points[i])
+	}
+	size := box.Size()
+	axis := byte(0)
+	if size.Y > size.X {
+		axis = 1
+	}
+
+	indices := t.indices[lo:hi]
+	sort.Slice(indices, func(a, b int) bool {
+		return coordinate(t.points[indices[a]], axis) < coordinate(t.points[indices[b]], axis)
+	})
+	mid := (lo + hi) / 2
+	t.axes[mid] = axis
+	t.build(lo, mid)
+	t.build(mid+1, hi)
+}
+
+// Len returns the number of points
+func (t *KDTree) Len() int {
+	return len(t.points)
+}
+
+// Point returns the point with the index
+func (t *KDTree) Point(i int) vector.Vector2 {
+	return t.points[i]
+}
+
+// Nearest returns the index of the point closest to the point p, or false if there are no points
+func (t *KDTree) Nearest(p vector.Vector2) (int, bool) {
+	nearest := t.KNearest(p, 1)
+	if len(nearest) == 0 {
+		return 0, false
+	}
+	return nearest[0], true
+}
+
+// KNearest returns the indices of the k points closest to the point p, from the closest to the farthest.
+// Points at the same distance are sorted by their index
+func (t *KDTree) KNearest(p vector.Vector2, k int) []int {
+	if k <= 0 {
+		return nil
+	}
+
+	// the queue keeps the k closest points found so far, with the farthest one on top
+	queue := &neighbourQueue{}
+	var search func(lo, hi int)
+	search = func(lo, hi int) {
+		if lo >= hi {
+			return
+		}
+		mid := (lo + hi) / 2
+		i := t.indices[mid]
+		candidate := neighbour{index: i, distanceSqr: t.points[i].DistanceSqr(p)}
+		if queue.Len() < k {
+			heap.Push(queue, candidate)
+		} else if candidate.closerThan((*queue)[0]) {
+			(*queue)[0] = candidate
+			heap.Fix(queue, 0)
+		}
+
+		// the side of the split holding the point is searched first, and the other one only if it can be closer
+		offset := coordinate(p, t.axes[mid]) - coordinate(t.points[i], t.axes[mid])
+		near, far := [2]int{lo, mid}, [2]int{mid + 1, hi}
+		if offset > 0 {
+			near, far = far, near
+		}
+		search(near[0], near[1])
+		if queue.Len() < k || offset*offset <= (*queue)[0].distanceSqr {
+			search(far[0], far[1])
+		}
+	}
+	search(0, len(t.indices))
+
+	result := make([]int, queue.Len())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = heap.Pop(queue).(neighbour).index
+	}
+	return result
+}
+
+// WithinRadius returns the indices of the points lying within the radius of the point p, boundary included,
+// in increasing order
+func (t *KDTree) WithinRadius(p vector.Vector2, radius float64) []int {
+	var result []int
+	radiusSqr := radius * radius
+	t.visit(func(offset float64, _ byte) (bool, bool) {
+		return offset >= -radius, offset <= radius
+	}, p, func(i int) {
+		if t.points[i].DistanceSqr(p) <= radiusSqr {
+			result = append(result, i)
+		}
+	})
+	sort.Ints(result)
+	return result
+}
+
+// Query returns the indices of the points lying inside the region, boundary included, in increasing order
+func (t *KDTree) Query(region bounds.AABB) []int {
+	var result []int
+	t.visit(func(coordinate float64, axis byte) (bool, bool) {
+		if axis == 0 {
+			return region.Min.X <= coordinate, region.Max.X >= coordinate
+		}
+		return region.Min.Y <= coordinate, region.Max.Y >= coordinate
+	}, vector.Vector2{}, func(i int) {
+		if region.ContainsPoint(t.points[i]) {
+			result = append(result, i)
+		}
+	})
+	sort.Ints(result)
+	return result
+}
+
+// visit calls the function f with the indices of the points in the branches accepted by the filter. The filter
+// receives the offset of each splitting point from the point p along its axis, and returns whether the lower and
+// the upper side of the split must be visited
+func (t *KDTree) visit(filter func(offset float64, axis byte) (lower, upper bool), p vector.Vector2, f func(i int)) {
+	var search func(lo, hi int)
+	search = func(lo, hi int) {
+		if lo >= hi {
+			return
+		}
+		mid := (lo + hi) / 2
+		i := t.indices[mid]
+		f(i)
+
+		axis := t.axes[mid]
+		offset := coordinate(t.points[i], axis) - coordinate(p, axis)
+		lower, upper := filter(offset, axis)
+		if lower {
+			search(lo, mid)
+		}
+		if upper {
+			search(mid+1, hi)
+		}
+	}
+	search(0, len(t.indices))
+}
+
+// coordinate returns the coordinate of the point along the axis, 0 for X and 1 for Y
+func coordinate(p vector.Vector2, axis byte) float64 {
+	if axis == 0 {
+		return p.X
+	}
+	return p.Y
+}
+
+// neighbour is a point found by a nearest neighbour search
+type neighbour struct {
+	index       int
+	distanceSqr float64
+}
+
+// closerThan checks if the neighbour is closer than the other one, breaking ties by their index
+func (n neighbour) closerThan(other neighbour) bool {
+	return n.distanceSqr < other.distanceSqr || (n.distanceSqr == other.distanceSqr && n.index < other.index)
+}
+
+// neighbourQueue is a priority queue of neighbours, with the farthest one first
+type neighbourQueue []neighbour
+
+func (q neighbourQueue) Len() int {
+	return len(q)
+}
+
+func (q neighbourQueue) Less(i, j int) bool {
+	return q[j].closerThan(q[i])
+}
+
+func (q neighbourQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *neighbourQueue) Push(x interface{}) {
+	*q = append(*q, x.(neighbour))
+}
+
+func (q *neighbourQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}
@@ -0,0 +1,195 @@
+package spatial
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// randomPoints returns points spread uniformly over the square [0, 1000]
+func randomPoints(r *rand.Rand, n int) []vector.Vector2 {
+	points := make([]vector.Vector2, n)
+	for i := range points {
+		points[i] = vector.Vector2{X: r.Float64() * 1000, Y: r.Float64() * 1000}
+	}
+	return points
+}
+
+// scanNearest returns the index of the point closest to the point p, checking every point
+func scanNearest(points []vector.Vector2, p vector.Vector2) int {
+	best := 0
+	for i := range points {
+		if points[i].DistanceSqr(p) < points[best].DistanceSqr(p) {
+			best = i
+		}
+	}
+	return best
+}
+
+// scanWithinRadius returns the indices of the points within the radius of the point p, checking every point
+func scanWithinRadius(points []vector.Vector2, p vector.Vector2, radius float64) []int {
+	var result []int
+	for i := range points {
+		if points[i].DistanceSqr(p) <= radius*radius {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// scanQuery returns the indices of the points inside the region, checking every point
+func scanQuery(points []vector.Vector2, region bounds.AABB) []int {
+	var result []int
+	for i := range points {
+		if region.ContainsPoint(points[i]) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// scanKNearest returns the indices of the k points closest to the point p, sorting every point by its distance and
+// then by its index
+func scanKNearest(points []vector.Vector2, p vector.Vector2, k int) []int {
+	indices := make([]int, len(points))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(a, b int) bool {
+		da, db := points[indices[a]].DistanceSqr(p), points[indices[b]].DistanceSqr(p)
+		if da != db {
+			return da < db
+		}
+		return indices[a] < indices[b]
+	})
+	if k < len(indices) {
+		indices = indices[:k]
+	}
+	return indices
+}
+
+// equalIndices determines whether both slices hold the same indices in the same order
+func equalIndices(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestKDTreeAgainstScan(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	points := randomPoints(r, 2000)
+	tree := NewKDTree(points)
+	for iteration := 0; iteration < 200; iteration++ {
+		p := vector.Vector2{X: r.Float64()*1200 - 100, Y: r.Float64()*1200 - 100}
+
+		if got, ok := tree.Nearest(p); !ok || points[got].DistanceSqr(p) != points[scanNearest(points, p)].DistanceSqr(p) {
+			t.Fatalf("nearest to %v: got %d", p, got)
+		}
+
+		radius := r.Float64() * 100
+		if got, want := tree.WithinRadius(p, radius), scanWithinRadius(points, p, radius); !equalIndices(got, want) {
+			t.Fatalf("within %v of %v: got %v, want %v", radius, p, got, want)
+		}
+
+		region := bounds.NewAABB(p, p.Add(vector.Vector2{X: r.Float64()*200 - 100, Y: r.Float64()*200 - 100}))
+		if got, want := tree.Query(region), scanQuery(points, region); !equalIndices(got, want) {
+			t.Fatalf("inside %v: got %v, want %v", region, got, want)
+		}
+	}
+}
+
+func TestKDTreeKNearest(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	grid := make([]vector.Vector2, 300)
+	for i := range grid {
+		grid[i] = vector.Vector2{X: float64(r.Intn(8)), Y: float64(r.Intn(8))}
+	}
+
+	// the points of a small grid repeat, and many of them lie at the same distance from the queries
+	tests := []struct {
+		name   string
+		points []vector.Vector2
+	}{{"random", randomPoints(r, 500)}, {"grid", grid}}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			points := test.points
+			tree := NewKDTree(points)
+			for iteration := 0; iteration < 300; iteration++ {
+				p := vector.Vector2{X: float64(r.Intn(10) - 1), Y: float64(r.Intn(10) - 1)}
+				if test.name == "random" {
+					p = vector.Vector2{X: r.Float64()*1200 - 100, Y: r.Float64()*1200 - 100}
+				}
+				k := 1 + r.Intn(40)
+				if iteration%10 == 0 {
+					k = len(points) + r.Intn(10)
+				}
+				if got, want := tree.KNearest(p, k), scanKNearest(points, p, k); !equalIndices(got, want) {
+					t.Fatalf("%d nearest to %v: got %v, want %v", k, p, got, want)
+				}
+			}
+		})
+	}
+
+	if got := NewKDTree(grid).KNearest(vector.Vector2{}, 0); len(got) != 0 {
+		t.Fatalf("no points should be returned for k = 0, got %v", got)
+	}
+	if got := NewKDTree(nil).KNearest(vector.Vector2{}, 3); len(got) != 0 {
+		t.Fatalf("an empty tree should return no points, got %v", got)
+	}
+}
+
+// benchmarkKDTree runs the query on a tree and on a linear scan of 1k, 10k and 100k points, for random query points
+func benchmarkKDTree(b *testing.B, tree func(t *KDTree, p vector.Vector2), scan func(points []vector.Vector2, p vector.Vector2)) {
+	for _, n := range []int{1000, 10000, 100000} {
+		r := rand.New(rand.NewSource(2))
+		points := randomPoints(r, n)
+		queries := randomPoints(r, 1024)
+		t := NewKDTree(points)
+
+		b.Run(fmt.Sprintf("tree/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				tree(t, queries[i%len(queries)])
+			}
+		})
+		b.Run(fmt.Sprintf("scan/%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				scan(points, queries[i%len(queries)])
+			}
+		})
+	}
+}
+
+func BenchmarkKDTreeNearest(b *testing.B) {
+	benchmarkKDTree(b, func(t *KDTree, p vector.Vector2) {
+		t.Nearest(p)
+	}, func(points []vector.Vector2, p vector.Vector2) {
+		scanNearest(points, p)
+	})
+}
+
+func BenchmarkKDTreeWithinRadius(b *testing.B) {
+	benchmarkKDTree(b, func(t *KDTree, p vector.Vector2) {
+		t.WithinRadius(p, 20)
+	}, func(points []vector.Vector2, p vector.Vector2) {
+		scanWithinRadius(points, p, 20)
+	})
+}
+
+func BenchmarkKDTreeQuery(b *testing.B) {
+	extents := vector.Vector2{X: 20, Y: 20}
+	benchmarkKDTree(b, func(t *KDTree, p vector.Vector2) {
+		t.Query(bounds.NewAABBFromCenter(p, extents))
+	}, func(points []vector.Vector2, p vector.Vector2) {
+		scanQuery(points, bounds.NewAABBFromCenter(p, extents))
+	})
+}
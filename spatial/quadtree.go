@@ -0,0 +1,222 @@
+package spatial
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Quadtree represents a loose quadtree over a region of the world. The bounds of each node are twice the size of its
+// cell, so every item is stored in a single node, the smallest one whose cell holds the center of its box and is at
+// least as large as the box. The items which do not fit in the region are stored in the root
+type Quadtree struct {
+	registry
+	root     *quadNode
+	maxDepth int
+	nodes    []*quadNode // node storing each item
+}
+
+// quadNode is a node of a loose quadtree
+type quadNode struct {
+	cell     bounds.AABB
+	loose    bounds.AABB
+	parent   *quadNode
+	children [4]*quadNode
+	items    []int
+}
+
+// NewQuadtree creates an empty quadtree over the region, whose nodes are split at most maxDepth times
+func NewQuadtree(region bounds.AABB, maxDepth int) *Quadtree {
+	return &Quadtree{root: newQuadNode(region, nil), maxDepth: maxDepth}
+}
+
+// newQuadNode creates a node for the cell
+func newQuadNode(cell bounds.AABB, parent *quadNode) *quadNode {
+	return &quadNode{cell: cell, loose: cell.ExpandBy(cell.Extents()), parent: parent}
+}
+
+// Insert adds an item bounded by the box. Returns the ID of the item
+func (q *Quadtree) Insert(box bounds.AABB) int {
+	id := q.add(box)
+	for len(q.nodes) <= id {
+		q.nodes = append(q.nodes, nil)
+	}
+	q.place(id, box)
+	return id
+}
+
+// Update changes the box of the item with the ID. The item only moves to another node if it no longer fits in its
+// node or if it fits in a smaller one
+func (q *Quadtree) Update(id int, box bounds.AABB) {
+	q.boxes[id] = box
+	if q.find(box, false) == q.nodes[id] {
+		return
+	}
+	q.unlink(id)
+	q.place(id, box)
+}
+
+// Remove removes the item with the ID, which may be reused by later insertions
+func (q *Quadtree) Remove(id int) {
+	q.unlink(id)
+	q.remove(id)
+}
+
+// Query returns the IDs of the items whose box overlaps the region, in increasing order
+func (q *Quadtree) Query(region bounds.AABB) []int {
+	var result []int
+	q.visit(func(n *quadNode) bool {
+		return n == q.root || n.loose.Overlaps(region)
+	}, func(id int) {
+		if q.boxes[id].Overlaps(region) {
+			result = append(result, id)
+		}
+	})
+	sort.Ints(result)
+	return result
+}
+
+// Raycast returns the items whose box is hit by the ray before the maximum parameter, a multiple of its
+// direction, sorted by the parameter where the ray enters their box
+func (q *Quadtree) Raycast(origin, direction vector.Vector2, max float64) []RayHit {
+	var hits []RayHit
+	q.visit(func(n *quadNode) bool {
+		if n == q.root {
+			return true
+		}
+		enter, _, ok := n.loose.IntersectRay(origin, direction)
+		return ok && enter <= max
+	}, func(id int) {
+		if enter, _, ok := q.boxes[id].IntersectRay(origin, direction); ok && enter <= max {
+			hits = append(hits, RayHit{ID: id, Parameter: enter})
+		}
+	})
+	return sortHits(hits)
+}
+
+// Nearest returns the ID of the item whose box is closest to the point, or false if there are no items.
+// The nodes are searched from the closest one, until no closer item can be found
+func (q *Quadtree) Nearest(p vector.Vector2) (int, bool) {
+	best, found := 0, false
+	bestDistance := 0.0
+	queue := &distanceQueue{{node: q.root}}
+	for queue.Len() != 0 {
+		entry := heap.Pop(queue).(distanceEntry)
+		if found && entry.distance > bestDistance {
+			break
+		}
+
+		n := entry.node.(*quadNode)
+		for _, id := range n.items {
+			if distance := q.boxes[id].Distance(p); !found || distance < bestDistance || (distance == bestDistance && id < best) {
+				best, bestDistance, found = id, distance, true
+			}
+		}
+		for _, child := range n.children {
+			if child != nil {
+				heap.Push(queue, distanceEntry{node: child, distance: child.loose.Distance(p)})
+			}
+		}
+	}
+	return best, found
+}
+
+// Pairs returns every pair of IDs of items whose boxes overlap, with the lowest ID first, in increasing order
+func (q *Quadtree) Pairs() [][2]int {
+	return findPairs(&q.registry, q.Query)
+}
+
+// find returns the node where an item with the box belongs, creating the missing nodes if asked to.
+// Returns nil if the node is missing and is not created
+func (q *Quadtree) find(box bounds.AABB, create bool) *quadNode {
+	n := q.root
+	if !n.cell.ContainsPoint(box.Center()) {
+		return n
+	}
+
+	size := box.Size()
+	for depth := 0; depth < q.maxDepth; depth++ {
+		half := n.cell.Extents()
+		if size.X > half.X || size.Y > half.Y {
+			break
+		}
+
+		// the quadrant holding the center of the box
+		center, middle := box.Center(), n.cell.Center()
+		quadrant := 0
+		if center.X >= middle.X {
+			quadrant |= 1
+		}
+		if center.Y >= middle.Y {
+			quadrant |= 2
+		}
+		if n.children[quadrant] == nil {
+			if !create {
+				return nil
+			}
+			min := n.cell.Min
+			if quadrant&1 != 0 {
+				min.X = middle.X
+			}
+			if quadrant&2 != 0 {
+				min.Y = middle.Y
+			}
+			n.children[quadrant] = newQuadNode(bounds.AABB{Min: min, Max: min.Add(half)}, n)
+		}
+		n = n.children[quadrant]
+	}
+	return n
+}
+
+// place stores the item in the node where its box belongs
+func (q *Quadtree) place(id int, box bounds.AABB) {
+	n := q.find(box, true)
+	n.items = append(n.items, id)
+	q.nodes[id] = n
+}
+
+// unlink removes the item from its node, removing the nodes left empty
+func (q *Quadtree) unlink(id int) {
+	n := q.nodes[id]
+	q.nodes[id] = nil
+	for i, other := range n.items {
+		if other == id {
+			n.items[i] = n.items[len(n.items)-1]
+			n.items = n.items[:len(n.items)-1]
+			break
+		}
+	}
+
+	for n != q.root && len(n.items) == 0 && n.children == [4]*quadNode{} {
+		parent := n.parent
+		for i, child := range parent.children {
+			if child == n {
+				parent.children[i] = nil
+			}
+		}
+		n = parent
+	}
+}
+
+// visit calls the function f with the items of every node accepted by the filter, skipping the descendants of the
+// nodes it rejects
+func (q *Quadtree) visit(filter func(n *quadNode) bool, f func(id int)) {
+	stack := []*quadNode{q.root}
+	for len(stack) != 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !filter(n) {
+			continue
+		}
+		for _, id := range n.items {
+			f(id)
+		}
+		for _, child := range n.children {
+			if child != nil {
+				stack = append(stack, child)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Package spatial provides broad-phase spatial indexes for finding the items lying in a region, along a ray or
+// near a point, among many moving items, and a static k-d tree for the same queries over fixed points
+package spatial
+
+import (
+	"sort"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// Index represents a spatial index of items, each identified by an ID and bounded by an axis-aligned box.
+// A point is stored as a box without size, such as bounds.NewAABB(p, p). It is implemented by HashGrid,
+// Quadtree and AABBTree
+type Index interface {
+	// Insert adds an item bounded by the box. Returns the ID of the item
+	Insert(box bounds.AABB) int
+	// Update changes the box of the item with the ID
+	Update(id int, box bounds.AABB)
+	// Remove removes the item with the ID, which may be reused by later insertions
+	Remove(id int)
+	// Box returns the box of the item with the ID
+	Box(id int) bounds.AABB
+	// Len returns the number of items
+	Len() int
+	// Query returns the IDs of the items whose box overlaps the region, in increasing order
+	Query(region bounds.AABB) []int
+	// Raycast returns the items whose box is hit by the ray before the maximum parameter, a multiple of its
+	// direction, sorted by the parameter where the ray enters their box
+	Raycast(origin, direction vector.Vector2, max float64) []RayHit
+	// Nearest returns the ID of the item whose box is closest to the point, or false if there are no items
+	Nearest(p vector.Vector2) (int, bool)
+	// Pairs returns every pair of IDs of items whose boxes overlap, with the lowest ID first, in increasing order
+	Pairs() [][2]int
+}
+
+// RayHit is an item whose box is hit by a ray
+type RayHit struct {
+	ID        int
+	Parameter float64 // multiple of the direction of the ray where it enters the box of the item
+}
+
+// registry holds the boxes of the items of an index, reusing the IDs of the removed ones
+type registry struct {
+	boxes []bounds.AABB
+	alive []bool
+	free  []int
+	count int
+}
+
+// add stores the box of a new item. Returns its ID
+func (r *registry) add(box bounds.AABB) (id int) {
+	r.count++
+	if len(r.free) != 0 {
+		id = r.free[len(r.free)-1]
+		r.free = r.free[:len(r.free)-1]
+		r.boxes[id] = box
+		r.alive[id] = true
+		return
+	}
+	r.boxes = append(r.boxes, box)
+	r.alive = append(r.alive, true)
+	return len(r.boxes) - 1
+}
+
+// remove forgets the item with the ID
+func (r *registry) remove(id int) {
+	r.count--
+	r.alive[id] = false
+	r.free = append(r.free, id)
+}
+
+// Box returns the box of the item with the ID
+func (r *registry) Box(id int) bounds.AABB {
+	return r.boxes[id]
+}
+
+// Len returns the number of items
+func (r *registry) Len() int {
+	return r.count
+}
+
+// nearest returns the item whose box is closest to the point by scanning every item
+func (r *registry) nearest(p vector.Vector2) (best int, ok bool) {
+	bestDistance := 0.0
+	for id, alive := range r.alive {
+		if !alive {
+			continue
+		}
+		if distance := r.boxes[id].Distance(p); !ok || distance < bestDistance {
+			best, bestDistance, ok = id, distance, true
+		}
+	}
+	return
+}
+
+// findPairs returns the pairs of overlapping items, querying the index with the box of every item
+func findPairs(r *registry, query func(region bounds.AABB) []int) [][2]int {
+	var pairs [][2]int
+	for id, alive := range r.alive {
+		if !alive {
+			continue
+		}
+		for _, other := range query(r.boxes[id]) {
+			if other > id {
+				pairs = append(pairs, [2]int{id, other})
+			}
+		}
+	}
+	return pairs
+}
+
+// sortHits sorts the ray hits by their parameter, and then by their ID
+func sortHits(hits []RayHit) []RayHit {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Parameter != hits[j].Parameter {
+			return hits[i].Parameter < hits[j].Parameter
+		}
+		return hits[i].ID < hits[j].ID
+	})
+	return hits
+}
@@ -0,0 +1,172 @@
+package spatial
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// randomBox returns a box around the square [0, 100], which may be large or have no size
+func randomBox(r *rand.Rand) bounds.AABB {
+	center := vector.Vector2{X: r.Float64()*120 - 10, Y: r.Float64()*120 - 10}
+	extents := vector.Vector2{X: r.Float64() * 3, Y: r.Float64() * 3}
+	switch r.Intn(10) {
+	case 0:
+		extents = extents.Mul(10)
+	case 1, 2:
+		extents = vector.Vector2{}
+	}
+	return bounds.NewAABBFromCenter(center, extents)
+}
+
+// bruteForce holds the boxes of the items alive in an index, answering its queries by scanning all of them
+type bruteForce map[int]bounds.AABB
+
+func (b bruteForce) query(region bounds.AABB) []int {
+	var result []int
+	for id, box := range b {
+		if box.Overlaps(region) {
+			result = append(result, id)
+		}
+	}
+	sort.Ints(result)
+	return result
+}
+
+func (b bruteForce) raycast(origin, direction vector.Vector2, max float64) []RayHit {
+	var hits []RayHit
+	for id, box := range b {
+		if hit, _, ok := box.IntersectRay(origin, direction); ok && hit <= max {
+			hits = append(hits, RayHit{ID: id, Parameter: hit})
+		}
+	}
+	return sortHits(hits)
+}
+
+func (b bruteForce) nearestDistance(p vector.Vector2) float64 {
+	best := math.Inf(1)
+	for _, box := range b {
+		best = math.Min(best, box.Distance(p))
+	}
+	return best
+}
+
+func (b bruteForce) pairs() [][2]int {
+	var pairs [][2]int
+	for id, box := range b {
+		for other, otherBox := range b {
+			if other > id && box.Overlaps(otherBox) {
+				pairs = append(pairs, [2]int{id, other})
+			}
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if pairs[i][0] != pairs[j][0] {
+			return pairs[i][0] < pairs[j][0]
+		}
+		return pairs[i][1] < pairs[j][1]
+	})
+	return pairs
+}
+
+func TestIndexAgainstBruteForce(t *testing.T) {
+	indices := map[string]func() Index{
+		"HashGrid": func() Index {
+			return NewHashGrid(4)
+		},
+		"Quadtree": func() Index {
+			return NewQuadtree(bounds.NewAABB(vector.Vector2{}, vector.Vector2{X: 100, Y: 100}), 6)
+		},
+		"AABBTree": func() Index {
+			return NewAABBTree(0.5)
+		},
+	}
+	for name, newIndex := range indices {
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			index := newIndex()
+			expected := bruteForce{}
+
+			// the items are inserted, moved and removed, so that their IDs are reused
+			for i := 0; i < 300; i++ {
+				box := randomBox(r)
+				expected[index.Insert(box)] = box
+			}
+			for round := 0; round < 3; round++ {
+				for id := range expected {
+					switch r.Intn(4) {
+					case 0:
+						index.Remove(id)
+						delete(expected, id)
+					case 1:
+						box := randomBox(r)
+						index.Update(id, box)
+						expected[id] = box
+					}
+				}
+				for i := 0; i < 50; i++ {
+					box := randomBox(r)
+					expected[index.Insert(box)] = box
+				}
+			}
+			if index.Len() != len(expected) {
+				t.Fatalf("got %d items, want %d", index.Len(), len(expected))
+			}
+
+			for iteration := 0; iteration < 300; iteration++ {
+				region := randomBox(r)
+				if got, want := index.Query(region), expected.query(region); !reflect.DeepEqual(got, want) {
+					t.Fatalf("query of %v: got %v, want %v", region, got, want)
+				}
+
+				p := vector.Vector2{X: r.Float64()*140 - 20, Y: r.Float64()*140 - 20}
+				if id, ok := index.Nearest(p); !ok || index.Box(id).Distance(p) != expected.nearestDistance(p) {
+					t.Fatalf("nearest to %v: got %d at %v, want a distance of %v", p, id, index.Box(id).Distance(p),
+						expected.nearestDistance(p))
+				}
+
+				// the rays include ones without direction and without a maximum parameter
+				direction := vector.Vector2{X: r.Float64()*2 - 1, Y: r.Float64()*2 - 1}
+				switch r.Intn(6) {
+				case 0:
+					direction = vector.Vector2{}
+				case 1:
+					direction.X = 0
+				case 2:
+					direction.Y = 0
+				}
+				max := r.Float64() * 200
+				if r.Intn(3) == 0 {
+					max = math.Inf(1)
+				}
+				if got, want := index.Raycast(p, direction, max), expected.raycast(p, direction, max); !reflect.DeepEqual(got, want) {
+					t.Fatalf("ray from %v along %v up to %v: got %v, want %v", p, direction, max, got, want)
+				}
+			}
+
+			if got, want := index.Pairs(), expected.pairs(); !reflect.DeepEqual(got, want) {
+				t.Fatalf("got the pairs %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestRaycastWithoutDirection(t *testing.T) {
+	for _, index := range []Index{
+		NewHashGrid(1),
+		NewQuadtree(bounds.NewAABB(vector.Vector2{}, vector.Vector2{X: 10, Y: 10}), 4),
+		NewAABBTree(0),
+	} {
+		index.Insert(bounds.NewAABB(vector.Vector2{}, vector.Vector2{X: 1, Y: 1}))
+		index.Insert(bounds.NewAABB(vector.Vector2{X: 2, Y: 2}, vector.Vector2{X: 3, Y: 3}))
+		got := index.Raycast(vector.Vector2{X: 0.5, Y: 0.5}, vector.Vector2{}, math.Inf(1))
+		if want := []RayHit{{ID: 0, Parameter: 0}}; !reflect.DeepEqual(got, want) {
+			t.Fatalf("%T: got %v, want %v", index, got, want)
+		}
+	}
+}
@@ -0,0 +1,384 @@
+package spatial
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/mindera-gaming/go-math/bounds"
+	vector "github.com/mindera-gaming/go-math/vector2"
+)
+
+// nullNode is the index of a missing node of an AABB tree
+const nullNode = -1
+
+// AABBTree represents a dynamic bounding volume hierarchy, a balanced binary tree of axis-aligned boxes.
+// Each leaf holds an item, bounded by its box enlarged by a margin, so that small movements do not change the tree.
+// The leaves are inserted where they enlarge the perimeter of the tree the least, and the tree is kept balanced
+// by rotating its nodes
+type AABBTree struct {
+	registry
+	margin    float64
+	nodes     []treeNode
+	freeNodes []int
+	root      int
+	leaves    []int // leaf of each item
+}
+
+// treeNode is a node of an AABB tree. A leaf has no children and holds an item
+type treeNode struct {
+	box    bounds.AABB
+	parent int
+	left   int
+	right  int
+	height int
+	id     int
+}
+
+// NewAABBTree creates an empty tree, whose leaves enlarge the boxes of the items by the margin on every side
+func NewAABBTree(margin float64) *AABBTree {
+	return &AABBTree{margin: margin, root: nullNode}
+}
+
+// isLeaf checks if the node has no children
+func (n *treeNode) isLeaf() bool {
+	return n.left == nullNode
+}
+
+// Insert adds an item bounded by the box. Returns the ID of the item
+func (t *AABBTree) Insert(box bounds.AABB) int {
+	id := t.add(box)
+	for len(t.leaves) <= id {
+		t.leaves = append(t.leaves, nullNode)
+	}
+	leaf := t.allocate()
+	t.nodes[leaf].box = box.Expand(t.margin)
+	t.nodes[leaf].id = id
+	t.leaves[id] = leaf
+	t.insertLeaf(leaf)
+	return id
+}
+
+// Update changes the box of the item with the ID. The tree only changes if the box leaves the enlarged box of its
+// leaf, which is enlarged again around the new box
+func (t *AABBTree) Update(id int, box bounds.AABB) {
+	t.boxes[id] = box
+	leaf := t.leaves[id]
+	if t.nodes[leaf].box.Contains(box) {
+		return
+	}
+	t.removeLeaf(leaf)
+	t.nodes[leaf].box = box.Expand(t.margin)
+	t.insertLeaf(leaf)
+}
+
+// Remove removes the item with the ID, which may be reused by later insertions
+func (t *AABBTree) Remove(id int) {
+	leaf := t.leaves[id]
+	t.removeLeaf(leaf)
+	t.release(leaf)
+	t.leaves[id] = nullNode
+	t.remove(id)
+}
+
+// Height returns the height of the tree, 0 if it holds a single item, or -1 if it is empty
+func (t *AABBTree) Height() int {
+	if t.root == nullNode {
+		return -1
+	}
+	return t.nodes[t.root].height
+}
+
+// Query returns the IDs of the items whose box overlaps the region, in increasing order
+func (t *AABBTree) Query(region bounds.AABB) []int {
+	var result []int
+	t.visit(func(box bounds.AABB) bool {
+		return box.Overlaps(region)
+	}, func(id int) {
+		if t.boxes[id].Overlaps(region) {
+			result = append(result, id)
+		}
+	})
+	sort.Ints(result)
+	return result
+}
+
+// Raycast returns the items whose box is hit by the ray before the maximum parameter, a multiple of its
+// direction, sorted by the parameter where the ray enters their box
+func (t *AABBTree) Raycast(origin, direction vector.Vector2, max float64) []RayHit {
+	var hits []RayHit
+	t.visit(func(box bounds.AABB) bool {
+		enter, _, ok := box.IntersectRay(origin, direction)
+		return ok && enter <= max
+	}, func(id int) {
+		if enter, _, ok := t.boxes[id].IntersectRay(origin, direction); ok && enter <= max {
+			hits = append(hits, RayHit{ID: id, Parameter: enter})
+		}
+	})
+	return sortHits(hits)
+}
+
+// Nearest returns the ID of the item whose box is closest to the point, or false if there are no items.
+// The nodes are searched from the closest one, until no closer item can be found
+func (t *AABBTree) Nearest(p vector.Vector2) (int, bool) {
+	best, found := 0, false
+	bestDistance := 0.0
+	if t.root == nullNode {
+		return best, found
+	}
+
+	queue := &distanceQueue{{node: t.root, distance: t.nodes[t.root].box.Distance(p)}}
+	for queue.Len() != 0 {
+		entry := heap.Pop(queue).(distanceEntry)
+		if found && entry.distance > bestDistance {
+			break
+		}
+
+		n := &t.nodes[entry.node.(int)]
+		if n.isLeaf() {
+			if distance := t.boxes[n.id].Distance(p); !found || distance < bestDistance || (distance == bestDistance && n.id < best) {
+				best, bestDistance, found = n.id, distance, true
+			}
+			continue
+		}
+		for _, child := range []int{n.left, n.right} {
+			heap.Push(queue, distanceEntry{node: child, distance: t.nodes[child].box.Distance(p)})
+		}
+	}
+	return best, found
+}
+
+// Pairs returns every pair of IDs of items whose boxes overlap, with the lowest ID first, in increasing order
+func (t *AABBTree) Pairs() [][2]int {
+	return findPairs(&t.registry, t.Query)
+}
+
+// visit calls the function f with the items of every leaf whose box, and the boxes of its ancestors,
+// are accepted by the filter
+func (t *AABBTree) visit(filter func(box bounds.AABB) bool, f func(id int)) {
+	if t.root == nullNode {
+		return
+	}
+
+	stack := []int{t.root}
+	for len(stack) != 0 {
+		n := &t.nodes[stack[len(stack)-1]]
+		stack = stack[:len(stack)-1]
+		if !filter(n.box) {
+			continue
+		}
+		if n.isLeaf() {
+			f(n.id)
+		} else {
+			stack = append(stack, n.left, n.right)
+		}
+	}
+}
+
+// allocate returns a new node without parent or children, reusing the released ones
+func (t *AABBTree) allocate() (index int) {
+	if len(t.freeNodes) != 0 {
+		index = t.freeNodes[len(t.freeNodes)-1]
+		t.freeNodes = t.freeNodes[:len(t.freeNodes)-1]
+	} else {
+		index = len(t.nodes)
+		t.nodes = append(t.nodes, treeNode{})
+	}
+	t.nodes[index] = treeNode{parent: nullNode, left: nullNode, right: nullNode}
+	return
+}
+
+// release makes the node available to later allocations
+func (t *AABBTree) release(index int) {
+	t.freeNodes = append(t.freeNodes, index)
+}
+
+// insertLeaf links the leaf to the tree, next to the sibling which enlarges the perimeter of the tree the least
+func (t *AABBTree) insertLeaf(leaf int) {
+	if t.root == nullNode {
+		t.root = leaf
+		t.nodes[leaf].parent = nullNode
+		return
+	}
+
+	// the tree is descended while the cost of a sibling further down, including the enlargement of the ancestors
+	// it inherits, can be lower than the cost of the current node
+	box := t.nodes[leaf].box
+	sibling := t.root
+	for !t.nodes[sibling].isLeaf() {
+		n := t.nodes[sibling]
+		combined := n.box.Union(box).Perimeter()
+		cost := 2 * combined
+		inheritance := 2 * (combined - n.box.Perimeter())
+
+		childCost := func(child int) float64 {
+			c := t.nodes[child]
+			if c.isLeaf() {
+				return c.box.Union(box).Perimeter() + inheritance
+			}
+			return c.box.Union(box).Perimeter() - c.box.Perimeter() + inheritance
+		}
+		leftCost, rightCost := childCost(n.left), childCost(n.right)
+		if cost < leftCost && cost < rightCost {
+			break
+		}
+		if leftCost < rightCost {
+			sibling = n.left
+		} else {
+			sibling = n.right
+		}
+	}
+
+	// a new parent joins the sibling and the leaf
+	oldParent := t.nodes[sibling].parent
+	parent := t.allocate()
+	t.nodes[parent].parent = oldParent
+	t.nodes[parent].box = t.nodes[sibling].box.Union(box)
+	t.nodes[parent].height = t.nodes[sibling].height + 1
+	t.nodes[parent].left = sibling
+	t.nodes[parent].right = leaf
+	t.nodes[sibling].parent = parent
+	t.nodes[leaf].parent = parent
+	if oldParent == nullNode {
+		t.root = parent
+	} else if t.nodes[oldParent].left == sibling {
+		t.nodes[oldParent].left = parent
+	} else {
+		t.nodes[oldParent].right = parent
+	}
+
+	t.refit(parent)
+}
+
+// removeLeaf unlinks the leaf from the tree, replacing its parent by its sibling
+func (t *AABBTree) removeLeaf(leaf int) {
+	if leaf == t.root {
+		t.root = nullNode
+		return
+	}
+
+	parent := t.nodes[leaf].parent
+	grandParent := t.nodes[parent].parent
+	sibling := t.nodes[parent].left
+	if sibling == leaf {
+		sibling = t.nodes[parent].right
+	}
+	t.release(parent)
+
+	t.nodes[sibling].parent = grandParent
+	if grandParent == nullNode {
+		t.root = sibling
+		return
+	}
+	if t.nodes[grandParent].left == parent {
+		t.nodes[grandParent].left = sibling
+	} else {
+		t.nodes[grandParent].right = sibling
+	}
+	t.refit(grandParent)
+}
+
+// refit balances the node and its ancestors, updating their boxes and heights
+func (t *AABBTree) refit(index int) {
+	for index != nullNode {
+		index = t.balance(index)
+		n := &t.nodes[index]
+		left, right := t.nodes[n.left], t.nodes[n.right]
+		n.height = 1 + maxInt(left.height, right.height)
+		n.box = left.box.Union(right.box)
+		index = n.parent
+	}
+}
+
+// balance rotates the node if one of its children is more than one level higher than the other one, promoting
+// that child. Returns the index of the node now in its place
+func (t *AABBTree) balance(a int) int {
+	na := &t.nodes[a]
+	if na.isLeaf() || na.height < 2 {
+		return a
+	}
+
+	b, c := na.left, na.right
+	switch difference := t.nodes[c].height - t.nodes[b].height; {
+	case difference > 1:
+		return t.rotate(a, c, b)
+	case difference < -1:
+		return t.rotate(a, b, c)
+	}
+	return a
+}
+
+// rotate promotes the child up of the node a in its place, where other is the other child of a.
+// The lower child of up takes its place under a. Returns the index of up
+func (t *AABBTree) rotate(a, up, other int) int {
+	f, g := t.nodes[up].left, t.nodes[up].right
+
+	// up takes the place of a
+	t.nodes[up].left = a
+	t.nodes[up].parent = t.nodes[a].parent
+	t.nodes[a].parent = up
+	if parent := t.nodes[up].parent; parent == nullNode {
+		t.root = up
+	} else if t.nodes[parent].left == a {
+		t.nodes[parent].left = up
+	} else {
+		t.nodes[parent].right = up
+	}
+
+	// the higher child of up stays with it, and the lower one replaces up under a
+	if t.nodes[f].height < t.nodes[g].height {
+		f, g = g, f
+	}
+	t.nodes[up].right = f
+	if t.nodes[a].left == up {
+		t.nodes[a].left = g
+	} else {
+		t.nodes[a].right = g
+	}
+	t.nodes[g].parent = a
+
+	t.nodes[a].box = t.nodes[other].box.Union(t.nodes[g].box)
+	t.nodes[a].height = 1 + maxInt(t.nodes[other].height, t.nodes[g].height)
+	t.nodes[up].box = t.nodes[a].box.Union(t.nodes[f].box)
+	t.nodes[up].height = 1 + maxInt(t.nodes[a].height, t.nodes[f].height)
+	return up
+}
+
+// maxInt returns the largest number
+func maxInt(a, b int) int {
+	if a >= b {
+		return a
+	}
+	return b
+}
+
+// distanceEntry is a node of a tree queued by its distance to a point
+type distanceEntry struct {
+	node     interface{}
+	distance float64
+}
+
+// distanceQueue is a priority queue of nodes, ordered by their distance to a point
+type distanceQueue []distanceEntry
+
+func (q distanceQueue) Len() int {
+	return len(q)
+}
+
+func (q distanceQueue) Less(i, j int) bool {
+	return q[i].distance < q[j].distance
+}
+
+func (q distanceQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *distanceQueue) Push(x interface{}) {
+	*q = append(*q, x.(distanceEntry))
+}
+
+func (q *distanceQueue) Pop() interface{} {
+	old := *q
+	entry := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return entry
+}